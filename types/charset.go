@@ -0,0 +1,74 @@
+package types
+
+// CharsetMaxBytes is the max number of bytes of a single character, keyed by lower case charset name.
+var CharsetMaxBytes = map[string]int{
+	// mysql
+	"armscii8": 1,
+	"ascii":    1,
+	"big5":     2,
+	"binary":   1,
+	"cp1250":   1,
+	"cp1251":   1,
+	"cp1256":   1,
+	"cp1257":   1,
+	"cp850":    1,
+	"cp852":    1,
+	"cp866":    1,
+	// sqlserver code pages
+	"cp874":    1,
+	"cp936":    2,
+	"cp949":    2,
+	"cp950":    2,
+	"cp1252":   1,
+	"cp1253":   1,
+	"cp1254":   1,
+	"cp1255":   1,
+	"cp1258":   1,
+	"cp932":    2,
+	"dec8":     1,
+	"eucjpms":  3,
+	"euckr":    2,
+	"gb18030":  4,
+	"gb2312":   2,
+	"gbk":      2,
+	"geostd8":  1,
+	"greek":    1,
+	"hebrew":   1,
+	"hp8":      1,
+	"keybcs2":  1,
+	"koi8r":    1,
+	"koi8u":    1,
+	"latin1":   1,
+	"latin2":   1,
+	"latin5":   1,
+	"latin7":   1,
+	"macce":    1,
+	"macroman": 1,
+	"sjis":     2,
+	"swe7":     1,
+	"tis620":   1,
+	"ucs2":     2,
+	"ujis":     3,
+	"utf16":    4,
+	"utf16le":  4,
+	"utf32":    4,
+	"utf8":     4, // mysql utf8 is an alias of utf8mb3, the mysql visitor resolves it
+	"utf8mb3":  3,
+	"utf8mb4":  4,
+	// postgresql
+	"euc_cn":    3,
+	"euc_jp":    3,
+	"euc_kr":    3,
+	"euc_tw":    4,
+	"sql_ascii": 1,
+	"win1252":   1,
+	"win1251":   1,
+	// oracle
+	"al32utf8":     4,
+	"al16utf16":    2, // oracle national lengths count utf-16 code units
+	"zhs16gbk":     2,
+	"zhs32gb18030": 4,
+	"we8iso8859p1": 1,
+	"we8mswin1252": 1,
+	"us7ascii":     1,
+}
@@ -0,0 +1,37 @@
+package types
+
+// ConstraintType is the kind of a table constraint.
+type ConstraintType = string
+
+const (
+	PrimaryKey ConstraintType = "primary key"
+	Unique     ConstraintType = "unique"
+	ForeignKey ConstraintType = "foreign key"
+	Check      ConstraintType = "check"
+)
+
+// Constraint is a table constraint, constraints declared on a column are recorded
+// as table constraints of that single column.
+type Constraint struct {
+	Name       string
+	Type       ConstraintType
+	Columns    []string
+	Check      string     // for check constraint, the expression as written
+	References *Reference // for foreign key constraint
+	Disabled   bool       // not enforced, e.g. oracle DISABLE
+	NoValidate bool       // existing rows are not checked, e.g. oracle ENABLE NOVALIDATE
+}
+
+// Reference is the referenced side of a foreign key.
+type Reference struct {
+	Table    string
+	Columns  []string // empty when the primary key of the referenced table is used
+	OnDelete string   // e.g. CASCADE, SET NULL, NO ACTION
+	OnUpdate string
+}
+
+// Generated describes a generated (computed) column.
+type Generated struct {
+	Expression string
+	Stored     bool // whether the value is stored rather than computed when read
+}
@@ -1,55 +1,142 @@
 package types
 
 var MySQLTypeMap = map[string]DbType{
-	"varchar":    String,
-	"char":       Char,
-	"text":       String,
-	"tinytext":   String,
-	"mediumtext": String,
-	"longtext":   String,
-	"int":        Integer,
-	"integer":    Integer,
-	"tinyint":    Integer,
-	"smallint":   Integer,
-	"mediumint":  Integer,
-	"bigint":     Integer,
-	"decimal":    Numeric,
-	"numeric":    Numeric,
-	"float":      Numeric,
-	"double":     Numeric,
-	"real":       Numeric,
-	"date":       Date,
-	"datetime":   DateTime,
-	"timestamp":  DateTime,
-	"time":       Time,
+	"varchar":            String,
+	"char":               Char,
+	"nvarchar":           String,
+	"nchar":              Char,
+	"text":               String,
+	"tinytext":           String,
+	"mediumtext":         String,
+	"longtext":           String,
+	"enum":               String,
+	"set":                String,
+	"json":               String,
+	"int":                Integer,
+	"integer":            Integer,
+	"tinyint":            Integer,
+	"smallint":           Integer,
+	"mediumint":          Integer,
+	"bigint":             Integer,
+	"serial":             Integer,
+	"bit":                Integer,
+	"year":               Integer,
+	"boolean":            Boolean,
+	"decimal":            Numeric,
+	"numeric":            Numeric,
+	"float":              Numeric,
+	"double":             Numeric,
+	"real":               Numeric,
+	"date":               Date,
+	"datetime":           DateTime,
+	"timestamp":          DateTime,
+	"time":               Time,
+	"binary":             Binary,
+	"varbinary":          Binary,
+	"tinyblob":           Binary,
+	"blob":               Binary,
+	"mediumblob":         Binary,
+	"longblob":           Binary,
+	"geometry":           Geometry,
+	"point":              Geometry,
+	"linestring":         Geometry,
+	"polygon":            Geometry,
+	"multipoint":         Geometry,
+	"multilinestring":    Geometry,
+	"multipolygon":       Geometry,
+	"geometrycollection": Geometry,
 }
 
+// PgTypeMap is keyed by the canonical type names, see the pg format_type function.
 var PgTypeMap = map[string]DbType{
-	"int2":      Integer,
-	"int4":      Integer,
-	"int8":      Integer,
-	"smallint":  Integer,
-	"integer":   Integer,
-	"bigint":    Integer,
-	"serial":    Integer,
-	"bigserial": Integer,
-	"timestamp": DateTime,
-	"date":      Date,
-	"time":      Time,
-	"bool":      Boolean,
-	"boolean":   Boolean,
-	"varchar":   String,
-	"char":      Char,
-	"text":      String,
-	"numeric":   Numeric,
-	"decimal":   Numeric,
-	"real":      Numeric,
-	"double":    Numeric,
+	"smallint":                    Integer,
+	"integer":                     Integer,
+	"bigint":                      Integer,
+	"smallserial":                 Integer,
+	"serial":                      Integer,
+	"bigserial":                   Integer,
+	"oid":                         Integer,
+	"xid":                         Integer,
+	"cid":                         Integer,
+	"numeric":                     Numeric,
+	"real":                        Numeric,
+	"double precision":            Numeric,
+	"money":                       Numeric,
+	"boolean":                     Boolean,
+	"character varying":           String,
+	"character":                   Char,
+	"text":                        String,
+	"name":                        String,
+	"bit":                         String,
+	"bit varying":                 String,
+	"uuid":                        String,
+	"json":                        String,
+	"jsonb":                       String,
+	"jsonpath":                    String,
+	"xml":                         String,
+	"inet":                        String,
+	"cidr":                        String,
+	"macaddr":                     String,
+	"macaddr8":                    String,
+	"tsvector":                    String,
+	"tsquery":                     String,
+	"interval":                    String,
+	"int4range":                   String,
+	"int8range":                   String,
+	"numrange":                    String,
+	"tsrange":                     String,
+	"tstzrange":                   String,
+	"daterange":                   String,
+	"int4multirange":              String,
+	"int8multirange":              String,
+	"nummultirange":               String,
+	"tsmultirange":                String,
+	"tstzmultirange":              String,
+	"datemultirange":              String,
+	"pg_lsn":                      String,
+	"pg_snapshot":                 String,
+	"txid_snapshot":               String,
+	"regclass":                    String,
+	"regtype":                     String,
+	"regproc":                     String,
+	"bytea":                       Binary,
+	"date":                        Date,
+	"time without time zone":      Time,
+	"time with time zone":         Time,
+	"timestamp without time zone": DateTime,
+	"timestamp with time zone":    DateTime,
+	"point":                       Geometry,
+	"line":                        Geometry,
+	"lseg":                        Geometry,
+	"box":                         Geometry,
+	"path":                        Geometry,
+	"polygon":                     Geometry,
+	"circle":                      Geometry,
 }
 
+// KingbaseTypeMap holds the pg types and the oracle and mysql compatible types of KingbaseES.
+var KingbaseTypeMap = extendTypeMap(PgTypeMap, map[string]DbType{
+	"tinyint":       Integer,
+	"mediumint":     Integer,
+	"number":        Numeric,
+	"binary_float":  Numeric,
+	"binary_double": Numeric,
+	"varchar2":      String,
+	"nvarchar2":     String,
+	"nvarchar":      String,
+	"nchar":         Char,
+	"clob":          String,
+	"nclob":         String,
+	"long":          String,
+	"blob":          Binary,
+	"raw":           Binary,
+	"datetime":      DateTime,
+})
+
 var PLSqlTypeMap = map[string]DbType{
 	"BINARY_INTEGER":   Integer,
 	"PLS_INTEGER":      Integer,
+	"SIMPLE_INTEGER":   Integer,
 	"NATURAL":          Integer,
 	"BINARY_FLOAT":     Numeric,
 	"BINARY_DOUBLE":    Numeric,
@@ -70,17 +157,17 @@ var PLSqlTypeMap = map[string]DbType{
 	"FLOAT":            Numeric,
 	"REAL":             Numeric,
 	"NCHAR":            Char,
-	// "LONG RAW":                       "",
-	"CHAR":      Char,
-	"CHARACTER": String,
-	"VARCHAR2":  String,
-	"VARCHAR":   String,
-	"STRING":    String,
-	"RAW":       "",
-	"BOOLEAN":   Boolean,
-	"DATE":      Date,
-	// "ROWID":                          "",
-	// "UROWID":                         "",
+	"LONG RAW":         Binary,
+	"CHAR":             Char,
+	"CHARACTER":        String,
+	"VARCHAR2":         String,
+	"VARCHAR":          String,
+	"STRING":           String,
+	"RAW":              Binary,
+	"BOOLEAN":          Boolean,
+	"DATE":             Date,
+	"ROWID":            String,
+	"UROWID":           String,
 	// "YEAR":                           "",
 	// "MONTH":                          "",
 	// "DAY":                            "",
@@ -97,19 +184,38 @@ var PLSqlTypeMap = map[string]DbType{
 	// "TIMESTAMP_LTZ_UNCONSTRAINED":    "",
 	// "YMINTERVAL_UNCONSTRAINED":       "",
 	// "DSINTERVAL_UNCONSTRAINED":       "",
-	// "BFILE":                          "",
-	// "BLOB":                           "",
-	// "CLOB":                           "",
-	// "NCLOB":                          "",
+	"BFILE": Binary,
+	"BLOB":  Binary,
+	"CLOB":  String,
+	"NCLOB": String,
 	// "MLSLABEL":                       "",
-	// "XMLTYPE":                        "",
-	// "LONG":                           "",
+	"XMLTYPE": String,
+	"LONG":    String,
 	// "INTERVAL YEAR TO MONTH":         "",
 	// "INTERVAL DAY TO SECOND":         "",
 	"TIMESTAMP WITH TIME ZONE":       DateTime,
 	"TIMESTAMP WITH LOCAL TIME ZONE": DateTime,
 }
 
+// DamengTypeMap holds the oracle types DM8 accepts and its own types, e.g. TEXT and IMAGE.
+var DamengTypeMap = extendTypeMap(PLSqlTypeMap, map[string]DbType{
+	"TINYINT":                 Integer,
+	"BYTE":                    Integer,
+	"BIGINT":                  Integer,
+	"BIT":                     Boolean,
+	"BOOL":                    Boolean,
+	"TEXT":                    String,
+	"LONGVARCHAR":             String,
+	"BINARY":                  Binary,
+	"VARBINARY":               Binary,
+	"IMAGE":                   Binary,
+	"LONGVARBINARY":           Binary,
+	"TIME":                    Time,
+	"TIME WITH TIME ZONE":     Time,
+	"DATETIME":                DateTime,
+	"DATETIME WITH TIME ZONE": DateTime,
+})
+
 var HiveTypeMap = map[string]DbType{
 	"tinyint":   Integer,
 	"smallint":  Integer,
@@ -134,35 +240,48 @@ var HiveTypeMap = map[string]DbType{
 	"decimal": Numeric,
 }
 
+// SqliteTypeMap holds the well-known sqlite type names, other names are resolved by SqliteAffinityMap.
 var SqliteTypeMap = map[string]DbType{
-	"INT":              Integer,
-	"INTEGER":          Integer,
-	"TINYINT":          Integer,
-	"SMALLINT":         Integer,
-	"MEDIUMINT":        Integer,
-	"BIGINT":           Integer,
-	"UNSIGNEDBIGINT":   Integer,
-	"INT2":             Integer,
-	"INT8":             Integer,
-	"CHARACTER":        String,
-	"VARCHAR":          String,
-	"VARYINGCHARACTER": String,
-	"CHAR":             Char,
-	"NCHAR":            Char,
-	"NATIVECHARACTER":  String,
-	"NVARCHAR":         String,
-	"TEXT":             String,
-	"CLOB":             String,
+	"INT":               Integer,
+	"INTEGER":           Integer,
+	"TINYINT":           Integer,
+	"SMALLINT":          Integer,
+	"MEDIUMINT":         Integer,
+	"BIGINT":            Integer,
+	"UNSIGNED BIG INT":  Integer,
+	"INT2":              Integer,
+	"INT8":              Integer,
+	"CHARACTER":         String,
+	"VARCHAR":           String,
+	"VARYING CHARACTER": String,
+	"CHAR":              Char,
+	"NCHAR":             Char,
+	"NATIVE CHARACTER":  String,
+	"NVARCHAR":          String,
+	"TEXT":              String,
+	"CLOB":              String,
 	// "BLOB":             "",
-	"REAL":            Numeric,
-	"DOUBLE":          Numeric,
-	"DOUBLEPRECISION": Numeric,
-	"FLOAT":           Numeric,
-	"NUMERIC":         Numeric,
-	"DECIMAL":         Numeric,
-	"BOOLEAN":         Boolean,
-	"DATE":            Date,
-	"DATETIME":        DateTime,
+	"REAL":             Numeric,
+	"DOUBLE":           Numeric,
+	"DOUBLE PRECISION": Numeric,
+	"FLOAT":            Numeric,
+	"NUMERIC":          Numeric,
+	"DECIMAL":          Numeric,
+	"BOOLEAN":          Boolean,
+	"BOOL":             Boolean,
+	"DATE":             Date,
+	"DATETIME":         DateTime,
+	"TIMESTAMP":        DateTime,
+	"TIME":             Time,
+}
+
+// SqliteAffinityMap maps the sqlite type affinities.
+var SqliteAffinityMap = map[string]DbType{
+	"BLOB":    Binary,
+	"INTEGER": Integer,
+	"TEXT":    String,
+	"REAL":    Numeric,
+	"NUMERIC": Numeric,
 }
 
 var TSqlTypeMap = map[string]DbType{
@@ -181,16 +300,16 @@ var TSqlTypeMap = map[string]DbType{
 	"time":       Time,
 	"datetime2":  DateTime,
 	// "datetimeoffset":   "",
-	"datetime": DateTime,
-	"char":     Char,
-	"varchar":  String,
-	"text":     String,
-	"nchar":    Char,
-	"nvarchar": String,
-	"ntext":    String,
-	// "binary":           "",
-	// "varbinary":        "",
-	// "image":            "",
+	"datetime":  DateTime,
+	"char":      Char,
+	"varchar":   String,
+	"text":      String,
+	"nchar":     Char,
+	"nvarchar":  String,
+	"ntext":     String,
+	"binary":    Binary,
+	"varbinary": Binary,
+	"image":     Binary,
 	// "cursor":           "",
 	// "geography":        "",
 	// "geometry":         "",
@@ -199,6 +318,18 @@ var TSqlTypeMap = map[string]DbType{
 	// "rowversion":       "",
 	// "sql_variant":      "",
 	// "table":            "",
-	// "uniqueidentifier": "",
+	"uniqueidentifier": String,
 	// "xml":              "",
 }
+
+// extendTypeMap returns a copy of base with the types of extra added.
+func extendTypeMap(base, extra map[string]DbType) map[string]DbType {
+	m := make(map[string]DbType, len(base)+len(extra))
+	for k, t := range base {
+		m[k] = t
+	}
+	for k, t := range extra {
+		m[k] = t
+	}
+	return m
+}
@@ -11,4 +11,7 @@ const (
 	Time     DbType = "time"
 	DateTime DbType = "datetime"
 	Boolean  DbType = "boolean"
+	Struct   DbType = "struct"
+	Binary   DbType = "binary"
+	Geometry DbType = "geometry"
 )
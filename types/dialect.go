@@ -9,4 +9,6 @@ const (
 	SQLServer  Dialect = "sqlserver"
 	SQLite3    Dialect = "sqlite3"
 	Hive       Dialect = "hive"
+	Dameng     Dialect = "dameng"   // DM8, parsed as oracle
+	KingbaseES Dialect = "kingbase" // parsed as postgres
 )
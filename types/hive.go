@@ -0,0 +1,50 @@
+package types
+
+// HiveTable holds the hive specific metadata of a table.
+type HiveTable struct {
+	External        bool
+	Temporary       bool
+	Transactional   bool
+	Managed         bool
+	Buckets         *HiveBuckets
+	Skewed          *HiveSkewed
+	RowFormat       *HiveRowFormat
+	FileFormat      string // STORED AS, e.g. ORC, PARQUET, TEXTFILE
+	InputFormat     string
+	OutputFormat    string
+	StorageHandler  string            // STORED BY
+	SerdeProperties map[string]string // WITH SERDEPROPERTIES of ROW FORMAT SERDE or STORED BY
+	Location        string
+	Properties      map[string]string // TBLPROPERTIES
+}
+
+// HiveBuckets is the CLUSTERED BY ... SORTED BY ... INTO n BUCKETS clause.
+type HiveBuckets struct {
+	Columns  []string
+	SortedBy []SortColumn
+	Count    int
+}
+
+// SortColumn is a column of a sort specification.
+type SortColumn struct {
+	Name string
+	Desc bool
+}
+
+// HiveSkewed is the SKEWED BY ... ON ... clause, Values holds one tuple per skewed value.
+type HiveSkewed struct {
+	Columns             []string
+	Values              [][]string
+	StoredAsDirectories bool
+}
+
+// HiveRowFormat is either ROW FORMAT SERDE or ROW FORMAT DELIMITED.
+type HiveRowFormat struct {
+	Serde                       string
+	FieldsTerminatedBy          string
+	EscapedBy                   string
+	CollectionItemsTerminatedBy string
+	MapKeysTerminatedBy         string
+	LinesTerminatedBy           string
+	NullDefinedAs               string
+}
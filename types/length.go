@@ -0,0 +1,9 @@
+package types
+
+// LengthUnit is the unit a string datatype declares its length in.
+type LengthUnit = string
+
+const (
+	Chars LengthUnit = "chars"
+	Bytes LengthUnit = "bytes"
+)
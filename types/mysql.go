@@ -0,0 +1,16 @@
+package types
+
+// MySQLTable holds the mysql specific options of a table.
+type MySQLTable struct {
+	Engine        string
+	RowFormat     string // DEFAULT, DYNAMIC, FIXED, COMPRESSED, REDUNDANT or COMPACT
+	AutoIncrement int64  // AUTO_INCREMENT start value, 0 when not set
+	KeyBlockSize  int
+}
+
+// MySQLColumn holds the mysql specific attributes of a column.
+type MySQLColumn struct {
+	ColumnFormat string // FIXED, DYNAMIC or DEFAULT
+	Storage      string // DISK, MEMORY or DEFAULT
+	SRID         int64  // spatial reference system of a geometry column, 0 when not set
+}
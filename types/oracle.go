@@ -0,0 +1,15 @@
+package types
+
+// OracleTable holds the oracle specific options of a table.
+type OracleTable struct {
+	SegmentCreation string // IMMEDIATE or DEFERRED of SEGMENT CREATION, empty when not declared
+	Lobs            []*OracleLob
+}
+
+// OracleLob is the storage of LOB columns, LOB (...) STORE AS [SECUREFILE | BASICFILE] segment (...).
+type OracleLob struct {
+	Columns    []string
+	Segment    string // name of the lob segment, empty when generated
+	SecureFile bool   // SECUREFILE, else BASICFILE
+	Tablespace string
+}
@@ -0,0 +1,40 @@
+package types
+
+// PartitionStrategy is how rows of a partitioned table are mapped to partitions.
+type PartitionStrategy = string
+
+const (
+	RangePartition PartitionStrategy = "range"
+	ListPartition  PartitionStrategy = "list"
+	HashPartition  PartitionStrategy = "hash"
+	KeyPartition   PartitionStrategy = "key" // mysql KEY partitioning, hashed by the server
+
+	// oracle only
+	ReferencePartition PartitionStrategy = "reference" // partitioned like the parent table of the foreign key in Keys
+	SystemPartition    PartitionStrategy = "system"    // the application picks the partition
+)
+
+// Partitioning describes how a table is partitioned.
+type Partitioning struct {
+	Strategy        PartitionStrategy // empty when the dialect has a single strategy, e.g. hive
+	Keys            []string          // partition key columns or expressions, e.g. 'dt' or 'bucket(16,id)'
+	Columns         []*AntlrColumn    // partition columns that are not part of the table columns, e.g. hive
+	Count           int               // number of partitions when not defined one by one, e.g. PARTITIONS 4
+	Interval        string            // interval of an oracle INTERVAL range partitioning, as written
+	Definitions     []*PartitionDefinition
+	Subpartitioning *Partitioning
+}
+
+// PartitionDefinition is a single partition, bounds and values are expressions as written.
+type PartitionDefinition struct {
+	Name          string
+	From          []string // inclusive lower bound of a range partition
+	To            []string // exclusive upper bound of a range partition, e.g. VALUES LESS THAN (...)
+	In            []string // values of a list partition, a multi-column value is a tuple, e.g. (1,'a')
+	Modulus       int      // modulus of a hash partition, e.g. pg FOR VALUES WITH (MODULUS 4, REMAINDER 0)
+	Remainder     int      // remainder of a hash partition
+	Default       bool     // the partition of the rows no other partition holds
+	Tablespace    string
+	Comment       string
+	Subpartitions []*PartitionDefinition
+}
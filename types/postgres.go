@@ -0,0 +1,8 @@
+package types
+
+// PostgresTable holds the postgres specific options of a table.
+type PostgresTable struct {
+	Inherits       []string             // parent tables of INHERITS (...)
+	PartitionOf    string               // parent table of a PARTITION OF table
+	PartitionBound *PartitionDefinition // FOR VALUES bound of a PARTITION OF table
+}
@@ -0,0 +1,7 @@
+package types
+
+// SQLiteTable holds the sqlite specific options of a table.
+type SQLiteTable struct {
+	WithoutRowid bool
+	Strict       bool
+}
@@ -0,0 +1,26 @@
+package types
+
+// SQLServerTable holds the sql server specific options of a table.
+type SQLServerTable struct {
+	PeriodStart     string // start column of PERIOD FOR SYSTEM_TIME, empty when not declared
+	PeriodEnd       string // end column of PERIOD FOR SYSTEM_TIME
+	SystemVersioned bool   // WITH (SYSTEM_VERSIONING = ON)
+	HistoryTable    string // HISTORY_TABLE of a system-versioned table, schema qualified
+	MemoryOptimized bool
+	Durability      string // SCHEMA_AND_DATA or SCHEMA_ONLY of a memory optimized table
+
+	// extended properties, e.g. MS_Description, added by sp_addextendedproperty
+	Properties           map[string]string
+	SchemaProperties     map[string]string            // of the schema of the table
+	IndexProperties      map[string]map[string]string // keyed by the index name
+	ConstraintProperties map[string]map[string]string // keyed by the constraint name
+}
+
+// SQLServerColumn holds the sql server specific attributes of a column.
+type SQLServerColumn struct {
+	GeneratedAlways string // ROW START, ROW END, TRANSACTION_ID START ... of GENERATED ALWAYS AS, system managed
+	Sparse          bool
+	RowGuidCol      bool
+	FileStream      bool
+	Properties      map[string]string // extended properties of the column
+}
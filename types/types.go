@@ -2,20 +2,65 @@ package types
 
 type AntlrColumn struct {
 	Name          string
+	Quoted        bool // whether the name was a quoted identifier
 	DataType      string
 	StringLength  int
-	MaxInteger    int64   // for integer datatype, max value
-	MinInteger    int64   // for integer datatype, only oracle 'SIGNTYPE' has min value, or 'bit' for tsql
-	MaxFloat      float64 // for float datatype, max value
+	LengthUnit    LengthUnit // for string datatype, unit of the declared length
+	LengthMax     bool       // for string datatype, declared as (max), e.g. tsql varchar(max)
+	CharLength    int        // for string datatype, max number of characters that always fit
+	ByteLength    int        // for string datatype, max length in bytes under the column charset
+	Charset       string     // for string datatype, the effective character set if known
+	Collation     string     // for string datatype, the column collation if declared
+	MaxInteger    int64      // for integer datatype, max value
+	MinInteger    int64      // for integer datatype, only oracle 'SIGNTYPE' has min value, or 'bit' for tsql, 'year' for mysql
+	Unsigned      bool       // for integer and numeric datatype, declared UNSIGNED or ZEROFILL
+	Zerofill      bool       // for integer and numeric datatype, values are displayed padded with zeros
+	MaxFloat      float64    // for float datatype, max value
 	Scale         int
 	Comment       string
+	NotNull       bool
+	Default       string     // default value expression as written, empty when not declared
+	DefaultName   string     // name of the default constraint, e.g. tsql CONSTRAINT DF_x DEFAULT 0
+	OnUpdate      string     // value expression set on update, e.g. mysql ON UPDATE CURRENT_TIMESTAMP
+	Invisible     bool       // hidden from SELECT *
+	Values        []string   // for enum and set datatype, the allowed values
+	Generated     *Generated // set for generated columns
 	AutoIncrement bool
+	Identity      *Identity        // set when AutoIncrement is true
+	Fields        []*AntlrColumn   // for struct datatype, the struct fields
+	MySQL         *MySQLColumn     // mysql specific attributes, nil for other dialects
+	SQLServer     *SQLServerColumn // sql server specific attributes, nil for other dialects
+}
+
+// Identity describes how the values of an auto-increment column are generated,
+// e.g. mysql AUTO_INCREMENT, tsql IDENTITY(1,1), pg serial / GENERATED AS IDENTITY,
+// or a sequence used by a default value or an oracle trigger.
+type Identity struct {
+	Seed           int64
+	Increment      int64
+	ExplicitInsert bool   // whether an explicit value can be inserted without extra settings
+	Sequence       string // backing sequence name, empty when the script does not name it, e.g. pg serial
 }
 
 type AntlrTable struct {
-	Dialect  Dialect
-	Database string
-	Name     string
-	Columns  []*AntlrColumn
-	Comment  string
+	Dialect    Dialect
+	Database   string // qualifier right before the table name, same as Schema, kept for compatibility
+	Catalog    string // database of a three-part name, e.g. 'test' of tsql test.dbo.mytable
+	Schema     string // schema of the table, mysql and hive databases are schemas
+	Name       string
+	Quoted     bool // whether the table name was a quoted identifier
+	Columns    []*AntlrColumn
+	Comment    string
+	Charset    string // default character set of the table
+	Collation  string // default collation of the table
+	Tablespace string
+
+	Constraints  []*Constraint
+	Partitioning *Partitioning
+	Hive         *HiveTable      // set for hive tables
+	MySQL        *MySQLTable     // set for mysql tables
+	SQLite       *SQLiteTable    // set for sqlite tables
+	Postgres     *PostgresTable  // set for postgres tables
+	SQLServer    *SQLServerTable // set for sql server tables
+	Oracle       *OracleTable    // set for oracle tables with storage options
 }
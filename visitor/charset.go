@@ -0,0 +1,35 @@
+package visitor
+
+import (
+	"github.com/aierdong/createtable-sql-parser/types"
+	"strings"
+)
+
+// maxBytesPerChar returns the max bytes of a single character in the charset,
+// an unknown or empty charset is treated as utf-8.
+func maxBytesPerChar(charset string) int {
+	if n, ok := types.CharsetMaxBytes[strings.ToLower(charset)]; ok {
+		return n
+	}
+	return 4
+}
+
+// resolveStringLength completes CharLength and ByteLength of a string column from the length
+// declared in its LengthUnit, the charset, and the max bytes the datatype can hold (0 for no limit).
+// StringLength is lowered so that generated values always fit.
+func resolveStringLength(col *types.AntlrColumn, charset string, maxBytes int) {
+	n := maxBytesPerChar(charset)
+	if col.LengthUnit == types.Bytes {
+		col.CharLength = col.ByteLength / n
+	} else if col.CharLength > 0 {
+		col.LengthUnit = types.Chars
+		col.ByteLength = col.CharLength * n
+	}
+	if maxBytes > 0 && col.ByteLength > maxBytes {
+		col.ByteLength = maxBytes
+		col.CharLength = min(col.CharLength, maxBytes/n)
+	}
+	if col.CharLength > 0 && col.StringLength > col.CharLength {
+		col.StringLength = col.CharLength
+	}
+}
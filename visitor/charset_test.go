@@ -0,0 +1,64 @@
+package visitor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aierdong/createtable-sql-parser/types"
+)
+
+func TestResolveStringLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		col      types.AntlrColumn
+		charset  string
+		maxBytes int
+		want     types.AntlrColumn
+	}{
+		{
+			name:    "chars in utf-8",
+			col:     types.AntlrColumn{StringLength: 10, CharLength: 10},
+			charset: "utf8mb4",
+			want:    types.AntlrColumn{StringLength: 10, CharLength: 10, ByteLength: 40, LengthUnit: types.Chars},
+		},
+		{
+			name:    "bytes in utf-8",
+			col:     types.AntlrColumn{StringLength: 10, ByteLength: 10, LengthUnit: types.Bytes},
+			charset: "al32utf8",
+			want:    types.AntlrColumn{StringLength: 2, CharLength: 2, ByteLength: 10, LengthUnit: types.Bytes},
+		},
+		{
+			name:    "bytes in a single byte charset",
+			col:     types.AntlrColumn{StringLength: 10, ByteLength: 10, LengthUnit: types.Bytes},
+			charset: "latin1",
+			want:    types.AntlrColumn{StringLength: 10, CharLength: 10, ByteLength: 10, LengthUnit: types.Bytes},
+		},
+		{
+			name:     "capped by the max bytes",
+			col:      types.AntlrColumn{StringLength: 50, CharLength: 4000},
+			charset:  "",
+			maxBytes: 4000,
+			want:     types.AntlrColumn{StringLength: 50, CharLength: 1000, ByteLength: 4000, LengthUnit: types.Chars},
+		},
+		{
+			name:    "unknown charset is utf-8",
+			col:     types.AntlrColumn{StringLength: 5, CharLength: 5},
+			charset: "foo",
+			want:    types.AntlrColumn{StringLength: 5, CharLength: 5, ByteLength: 20, LengthUnit: types.Chars},
+		},
+		{
+			name: "no length",
+			col:  types.AntlrColumn{StringLength: 50},
+			want: types.AntlrColumn{StringLength: 50},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := tt.col
+			resolveStringLength(&col, tt.charset, tt.maxBytes)
+			if !reflect.DeepEqual(col, tt.want) {
+				t.Errorf("resolveStringLength(%+v, %q, %d) = %+v, want %+v", tt.col, tt.charset, tt.maxBytes, col, tt.want)
+			}
+		})
+	}
+}
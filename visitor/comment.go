@@ -0,0 +1,143 @@
+package visitor
+
+import (
+	"github.com/antlr4-go/antlr/v4"
+	"strings"
+)
+
+// inlineComment returns the -- or /* */ comments documenting a column or another table element:
+// the comments following it on the same line, after the separating comma if any, or else the
+// block of comments right above it. It returns "" when stream is nil.
+func inlineComment(stream *antlr.CommonTokenStream, ctx antlr.ParserRuleContext) string {
+	if stream == nil || ctx.GetStart() == nil || ctx.GetStop() == nil {
+		return ""
+	}
+	if comment := trailingComment(stream, ctx.GetStop()); comment != "" {
+		return comment
+	}
+	return leadingComment(stream, ctx.GetStart())
+}
+
+// inlineTableComment returns the comments right above the create table statement, or else
+// the comments following the opening parenthesis of the column list on the same line.
+func inlineTableComment(stream *antlr.CommonTokenStream, ctx antlr.ParserRuleContext, open antlr.TerminalNode) string {
+	if stream == nil || ctx.GetStart() == nil {
+		return ""
+	}
+	if comment := leadingComment(stream, ctx.GetStart()); comment != "" {
+		return comment
+	}
+	if open == nil {
+		return ""
+	}
+	return trailingComment(stream, open.GetSymbol())
+}
+
+// trailingComment returns the comments after token on the same line.
+func trailingComment(stream *antlr.CommonTokenStream, token antlr.Token) string {
+	stream.Fill()
+	line := token.GetLine()
+	hidden := stream.GetHiddenTokensToRight(token.GetTokenIndex(), antlr.TokenHiddenChannel)
+	if next := stream.NextTokenOnChannel(token.GetTokenIndex()+1, antlr.TokenDefaultChannel); next > 0 {
+		if t := stream.Get(next); t.GetText() == "," && t.GetLine() == line {
+			hidden = append(hidden, stream.GetHiddenTokensToRight(next, antlr.TokenHiddenChannel)...)
+		}
+	}
+
+	var comments []string
+	for _, t := range hidden {
+		if t.GetLine() != line {
+			break
+		}
+		if isComment(t.GetText()) {
+			comments = append(comments, cleanComment(t.GetText()))
+		}
+	}
+	return strings.TrimSpace(strings.Join(comments, "\n"))
+}
+
+// leadingComment returns the block of comments right above token, comments separated from it by
+// a blank line or on the same line as the previous token are not part of the block.
+func leadingComment(stream *antlr.CommonTokenStream, token antlr.Token) string {
+	stream.Fill()
+	prevLine := 0
+	for i := token.GetTokenIndex() - 1; i >= 0; i-- {
+		if t := stream.Get(i); t.GetChannel() == antlr.TokenDefaultChannel {
+			prevLine = lastLine(t)
+			break
+		}
+	}
+
+	var comments []string
+	boundary := token.GetLine()
+	hidden := stream.GetHiddenTokensToLeft(token.GetTokenIndex(), antlr.TokenHiddenChannel)
+	for i := len(hidden) - 1; i >= 0; i-- {
+		t := hidden[i]
+		if !isComment(t.GetText()) {
+			continue
+		}
+		if t.GetLine() <= prevLine || lastLine(t) < boundary-1 {
+			break
+		}
+		comments = append([]string{cleanComment(t.GetText())}, comments...)
+		boundary = t.GetLine()
+	}
+	return strings.TrimSpace(strings.Join(comments, "\n"))
+}
+
+// lastLine returns the line the token ends on, the line break ending a line comment is not counted.
+func lastLine(token antlr.Token) int {
+	return token.GetLine() + strings.Count(strings.TrimRight(token.GetText(), "\r\n"), "\n")
+}
+
+// isComment reports whether the hidden token is a comment, mysql versioned comments and optimizer
+// hints are not.
+func isComment(text string) bool {
+	switch {
+	case strings.HasPrefix(text, "--"), strings.HasPrefix(text, "#"):
+		return true
+	case strings.HasPrefix(text, "/*!"), strings.HasPrefix(text, "/*+"):
+		return false
+	}
+	return strings.HasPrefix(text, "/*") && strings.HasSuffix(text, "*/")
+}
+
+// cleanComment strips the comment markers and the leading asterisks of block comment lines.
+func cleanComment(text string) string {
+	if strings.HasPrefix(text, "--") || strings.HasPrefix(text, "#") {
+		return strings.TrimSpace(strings.TrimLeft(text, "-#"))
+	}
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "*"))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// skipLeadingComments returns s without the white spaces and comments it starts with,
+// so that the statement keyword can be matched.
+func skipLeadingComments(s string) string {
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		switch {
+		case strings.HasPrefix(s, "--"):
+			if i := strings.IndexByte(s, '\n'); i >= 0 {
+				s = s[i+1:]
+			} else {
+				return ""
+			}
+		case strings.HasPrefix(s, "/*") && !strings.HasPrefix(s, "/*!"):
+			if i := strings.Index(s[2:], "*/"); i >= 0 {
+				s = s[i+4:]
+			} else {
+				return ""
+			}
+		default:
+			return s
+		}
+	}
+}
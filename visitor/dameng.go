@@ -0,0 +1,83 @@
+package visitor
+
+import (
+	"github.com/aierdong/createtable-sql-parser/types"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ParseDamengSql parses the DDL of DM8 (Dameng), which is oracle compatible. The DM8 types and
+// IDENTITY(seed, increment) columns the oracle grammar does not accept are read from the text.
+func ParseDamengSql(sql string, opts ...Option) (*types.AntlrTable, error) {
+	return parsePlSqlScript(sql, types.Dameng, opts)
+}
+
+// damengType is a DM8 column type, e.g. VARBINARY(100) or DATETIME(6) WITH TIME ZONE.
+type damengType struct {
+	name   string // upper case, e.g. DATETIME WITH TIME ZONE
+	length int
+	scale  int
+}
+
+var (
+	damengTypeRegexp = regexp.MustCompile(`(?i)^\s+(TEXT|LONGVARCHAR|IMAGE|LONGVARBINARY|BINARY|VARBINARY|BIT|TINYINT|BYTE|BIGINT|BOOL|DATETIME|TIME)\b` +
+		`(?:\s*\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\))?(\s+WITH\s+TIME\s+ZONE\b)?`)
+	damengIdentityRegexp = regexp.MustCompile(`(?i)\bIDENTITY\b(?:\s*\(\s*(-?\d+)\s*,\s*(-?\d+)\s*\))?`)
+	damengAsRegexp       = regexp.MustCompile(`(?i)\bAS\s*$`)
+)
+
+// extractDamengColumns reads the DM8 types and the IDENTITY clauses of the column definitions,
+// which the grammar does not accept. They are blanked out of the returned statement, keeping
+// the offsets of the rest, and keyed by column name. The oracle GENERATED AS IDENTITY is kept.
+func extractDamengColumns(sql string) (string, map[string]damengType, map[string]*types.Identity) {
+	b := []byte(sql)
+	masked := maskOracleText(sql)
+	dmTypes := make(map[string]damengType)
+	identities := make(map[string]*types.Identity)
+	forEachRelationalProperty(masked, func(start, end int) {
+		name, typeEnd, ok := oracleColumnName(sql, start, end)
+		if !ok {
+			return
+		}
+
+		if t := damengTypeRegexp.FindStringSubmatchIndex(masked[typeEnd:end]); t != nil {
+			dmType := damengType{name: strings.ToUpper(masked[typeEnd+t[2] : typeEnd+t[3]])}
+			if t[4] >= 0 {
+				dmType.length, _ = strconv.Atoi(masked[typeEnd+t[4] : typeEnd+t[5]])
+			}
+			if t[6] >= 0 {
+				dmType.scale, _ = strconv.Atoi(masked[typeEnd+t[6] : typeEnd+t[7]])
+			}
+			if t[8] >= 0 {
+				dmType.name += " WITH TIME ZONE"
+			}
+			dmTypes[name] = dmType
+			blankRange(b, typeEnd, typeEnd+t[1])
+			typeEnd += t[1]
+		}
+
+		id := indexTopLevel(damengIdentityRegexp, masked, typeEnd, end)
+		if id == nil || damengAsRegexp.MatchString(masked[typeEnd:id[0]]) {
+			return
+		}
+		identity := newIdentity(false)
+		if id[2] >= 0 {
+			identity.Seed, _ = strconv.ParseInt(masked[id[2]:id[3]], 10, 64)
+			identity.Increment, _ = strconv.ParseInt(masked[id[4]:id[5]], 10, 64)
+		}
+		identities[name] = identity
+		blankRange(b, id[0], id[1])
+	})
+	return string(b), dmTypes, identities
+}
+
+// parseDamengType maps a DM8 type read from the text and initializes the column.
+func (v *OracleVisitor) parseDamengType(t damengType) (*types.AntlrColumn, error) {
+	column, err := v.mapColumnType(t.name)
+	if err != nil {
+		return nil, err
+	}
+	v.setColumnAttributes(column, t.name, t.length, t.scale)
+	return column, nil
+}
@@ -0,0 +1,92 @@
+package visitor
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aierdong/createtable-sql-parser/types"
+)
+
+func TestExtractDamengColumns(t *testing.T) {
+	sql := `CREATE TABLE t (
+	id INT IDENTITY(100, 2),
+	a TEXT DEFAULT 'IMAGE',
+	b DATETIME(6) WITH TIME ZONE,
+	c VARBINARY(16) /* BIT */,
+	d NUMBER GENERATED ALWAYS AS IDENTITY,
+	CONSTRAINT pk PRIMARY KEY (id)
+)`
+	got, dmTypes, identities := extractDamengColumns(sql)
+
+	wantTypes := map[string]damengType{
+		"A": {name: "TEXT"},
+		"B": {name: "DATETIME WITH TIME ZONE", length: 6},
+		"C": {name: "VARBINARY", length: 16},
+	}
+	if !reflect.DeepEqual(dmTypes, wantTypes) {
+		t.Errorf("types = %+v, want %+v", dmTypes, wantTypes)
+	}
+	wantIdentities := map[string]*types.Identity{"ID": {Seed: 100, Increment: 2}}
+	if !reflect.DeepEqual(identities, wantIdentities) {
+		t.Errorf("identities = %+v, want %+v", identities, wantIdentities)
+	}
+	if len(got) != len(sql) || strings.Contains(got, "IDENTITY(") || strings.Contains(got, "TEXT") ||
+		!strings.Contains(got, "GENERATED ALWAYS AS IDENTITY") {
+		t.Errorf("sql = %q, want the dm8 types and IDENTITY(100, 2) blanked out", got)
+	}
+}
+
+func TestParseDamengSql(t *testing.T) {
+	table, err := ParseDamengSql(`CREATE TABLE "SYSDBA"."T" (
+	"ID" INT IDENTITY(1, 1) NOT NULL,
+	"A" TEXT,
+	"B" IMAGE,
+	"C" BIT,
+	"D" LONGVARCHAR,
+	"E" TINYINT,
+	"F" SMALLINT,
+	"G" DATETIME(6) WITH TIME ZONE,
+	"H" VARCHAR2(20 CHAR),
+	CONSTRAINT "T_PK" PRIMARY KEY ("ID")
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.Dialect != types.Dameng || table.Schema != "SYSDBA" || table.Name != "T" {
+		t.Errorf("table = %s %s.%s, want dameng SYSDBA.T", table.Dialect, table.Schema, table.Name)
+	}
+
+	tests := []struct {
+		name         string
+		dataType     types.DbType
+		maxInteger   int64
+		stringLength int
+	}{
+		{"ID", types.Integer, math.MaxInt32, 0},
+		{"A", types.String, 0, 50},
+		{"B", types.Binary, 0, 50},
+		{"C", types.Boolean, 0, 0},
+		{"D", types.String, 0, 50},
+		{"E", types.Integer, math.MaxInt8, 0},
+		{"F", types.Integer, math.MaxInt16, 0},
+		{"G", types.DateTime, 0, 0},
+		{"H", types.String, 0, 20},
+	}
+	if len(table.Columns) != len(tests) {
+		t.Fatalf("columns = %d, want %d", len(table.Columns), len(tests))
+	}
+	for i, tt := range tests {
+		c := table.Columns[i]
+		if c.Name != tt.name || c.DataType != tt.dataType || c.MaxInteger != tt.maxInteger || c.StringLength != tt.stringLength {
+			t.Errorf("%s: DataType = %s, MaxInteger = %d, StringLength = %d, want %s, %d, %d",
+				c.Name, c.DataType, c.MaxInteger, c.StringLength, tt.dataType, tt.maxInteger, tt.stringLength)
+		}
+	}
+
+	id := table.Columns[0]
+	if !id.AutoIncrement || !reflect.DeepEqual(id.Identity, &types.Identity{Seed: 1, Increment: 1}) || !id.NotNull {
+		t.Errorf("ID: AutoIncrement = %v, Identity = %+v, NotNull = %v, want IDENTITY(1, 1) not null", id.AutoIncrement, id.Identity, id.NotNull)
+	}
+}
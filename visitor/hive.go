@@ -16,9 +16,11 @@ type HiveVisitor struct {
 	*parser.BaseHiveParserVisitor
 	Table *types.AntlrTable
 	Err   error
+
+	comments *antlr.CommonTokenStream // set when inline comments are used
 }
 
-func ParseHiveSql(sql string) (table *types.AntlrTable, err error) {
+func ParseHiveSql(sql string, opts ...Option) (table *types.AntlrTable, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			table = nil
@@ -41,6 +43,9 @@ func ParseHiveSql(sql string) (table *types.AntlrTable, err error) {
 			Columns: make([]*types.AntlrColumn, 0),
 		},
 	}
+	if newOptions(opts).inlineComments {
+		visitor.comments = stream
+	}
 	tree.Accept(visitor)
 
 	if visitor.Err != nil {
@@ -57,38 +62,269 @@ func (v *HiveVisitor) VisitCreateTableStatement(ctx *parser.CreateTableStatement
 		return nil
 	}
 
-	dbName, tableName := v.resolveTableName(ctx.TableName().GetText())
-	v.Table.Database = dbName
-	v.Table.Name = tableName
+	v.resolveTableName(ctx.TableName().GetText())
+	if ctx.TableComment() != nil {
+		v.Table.Comment = decodeString(types.Hive, ctx.TableComment().GetComment().GetText())
+	} else {
+		v.Table.Comment = inlineTableComment(v.comments, ctx, ctx.LPAREN())
+	}
 
 	for _, child := range ctx.ColumnNameTypeOrConstraintList().(*parser.ColumnNameTypeOrConstraintListContext).AllColumnNameTypeOrConstraint() {
 		colDef := child.ColumnNameTypeConstraint()
-		if colDef.Id_() == nil || colDef.ColType() == nil {
-			v.Err = errors.New("column definition, name, or field is nil")
+		if colDef == nil {
+			continue
+		}
+		column, err := v.parseColumnDef(colDef)
+		if err != nil {
+			v.Err = err
 			return nil
 		}
+		v.Table.Columns = append(v.Table.Columns, column)
+	}
 
-		// dataType: integer, string..., and length, scala
-		column, err := v.parseColumnType(colDef.ColType().GetText())
-		if err != nil {
+	v.Table.Hive = &types.HiveTable{
+		External:      ctx.KW_EXTERNAL() != nil,
+		Temporary:     ctx.KW_TEMPORARY() != nil,
+		Transactional: ctx.KW_TRANSACTIONAL() != nil,
+		Managed:       ctx.KW_MANAGED() != nil,
+	}
+	if ctx.CreateTablePartitionSpec() != nil {
+		if err := v.setPartitioning(ctx.CreateTablePartitionSpec()); err != nil {
 			v.Err = err
 			return nil
 		}
-		column.Name = strings.Trim(colDef.Id_().GetText(), "`")
+	}
+	if ctx.TableBuckets() != nil {
+		v.setBuckets(ctx.TableBuckets())
+	}
+	if ctx.TableSkewed() != nil {
+		v.setSkewed(ctx.TableSkewed())
+	}
+	if ctx.TableRowFormat() != nil {
+		v.setRowFormat(ctx.TableRowFormat())
+	}
+	if ctx.TableFileFormat() != nil {
+		v.setFileFormat(ctx.TableFileFormat())
+	}
+	if ctx.TableLocation() != nil {
+		v.Table.Hive.Location = decodeString(types.Hive, ctx.TableLocation().GetLocn().GetText())
+	}
+	if ctx.TablePropertiesPrefixed() != nil {
+		v.Table.Hive.Properties = v.getProperties(ctx.TablePropertiesPrefixed().TableProperties())
+	}
+	return nil
+}
 
-		v.Table.Columns = append(v.Table.Columns, column)
+// parseColumnDef parses a column definition of the table or of the PARTITIONED BY clause.
+func (v *HiveVisitor) parseColumnDef(colDef parser.IColumnNameTypeConstraintContext) (*types.AntlrColumn, error) {
+	if colDef.Id_() == nil || colDef.ColType() == nil {
+		return nil, errors.New("column definition, name, or field is nil")
+	}
+
+	column, err := v.parseColType(colDef.ColType())
+	if err != nil {
+		return nil, err
+	}
+	column.Name, column.Quoted = normalizeIdentifier(types.Hive, colDef.Id_().GetText())
+	if colDef.GetComment() != nil {
+		column.Comment = decodeString(types.Hive, colDef.GetComment().GetText())
+	} else {
+		column.Comment = inlineComment(v.comments, colDef)
+	}
+	return column, nil
+}
+
+// parseColType parses the column type, struct fields are parsed recursively with their comments.
+func (v *HiveVisitor) parseColType(ctx parser.IColTypeContext) (*types.AntlrColumn, error) {
+	structType := ctx.Type_().StructType()
+	if structType == nil {
+		// dataType: integer, string..., and length, scala
+		return v.parseColumnType(ctx.GetText())
+	}
+
+	column := &types.AntlrColumn{DataType: types.Struct}
+	for _, field := range structType.ColumnNameColonTypeList().AllColumnNameColonType() {
+		fieldColumn, err := v.parseColType(field.ColType())
+		if err != nil {
+			return nil, err
+		}
+		fieldColumn.Name, fieldColumn.Quoted = normalizeIdentifier(types.Hive, field.Id_().GetText())
+		if field.GetComment() != nil {
+			fieldColumn.Comment = decodeString(types.Hive, field.GetComment().GetText())
+		}
+		column.Fields = append(column.Fields, fieldColumn)
 	}
+	return column, nil
+}
+
+// setPartitioning sets the PARTITIONED BY columns, they are kept apart from the table columns
+// since hive stores them in the directory layout rather than in the data files.
+func (v *HiveVisitor) setPartitioning(ctx parser.ICreateTablePartitionSpecContext) error {
+	partitioning := &types.Partitioning{}
+	switch {
+	case ctx.CreateTablePartitionColumnTypeSpec() != nil:
+		for _, colDef := range ctx.CreateTablePartitionColumnTypeSpec().AllColumnNameTypeConstraint() {
+			column, err := v.parseColumnDef(colDef)
+			if err != nil {
+				return err
+			}
+			partitioning.Keys = append(partitioning.Keys, column.Name)
+			partitioning.Columns = append(partitioning.Columns, column)
+		}
+	case ctx.CreateTablePartitionColumnSpec() != nil:
+		for _, name := range ctx.CreateTablePartitionColumnSpec().AllColumnName() {
+			partitioning.Keys = append(partitioning.Keys, v.getColumnName(name))
+		}
+	case ctx.PartitionTransformSpec() != nil:
+		// iceberg partition transforms, e.g. PARTITIONED BY SPEC (year(ts), bucket(16, id))
+		for _, spec := range ctx.PartitionTransformSpec().AllColumnNameTransformConstraint() {
+			transform := spec.PartitionTransformType()
+			if transform.LPAREN() == nil {
+				partitioning.Keys = append(partitioning.Keys, v.getColumnName(transform.ColumnName()))
+				continue
+			}
+			partitioning.Keys = append(partitioning.Keys, strings.ToLower(transform.GetText()))
+		}
+	}
+	v.Table.Partitioning = partitioning
 	return nil
 }
 
-func (v *HiveVisitor) resolveTableName(tableName string) (database string, table string) {
-	parts := strings.Split(tableName, ".")
-	table = strings.Trim(parts[len(parts)-1], "`")
-	database = "default"
-	if len(parts) >= 2 {
-		database = strings.Trim(parts[0], "`")
+// setBuckets sets the CLUSTERED BY ... SORTED BY ... INTO n BUCKETS clause.
+func (v *HiveVisitor) setBuckets(ctx parser.ITableBucketsContext) {
+	buckets := &types.HiveBuckets{
+		Columns: v.getColumnNames(ctx.GetBucketCols()),
+	}
+	buckets.Count, _ = strconv.Atoi(ctx.GetNum().GetText())
+	if ctx.GetSortCols() != nil {
+		for _, col := range ctx.GetSortCols().AllColumnNameOrder() {
+			name, _ := normalizeIdentifier(types.Hive, col.Id_().GetText())
+			buckets.SortedBy = append(buckets.SortedBy, types.SortColumn{
+				Name: name,
+				Desc: col.GetOrderSpec() != nil && col.GetOrderSpec().KW_DESC() != nil,
+			})
+		}
+	}
+	v.Table.Hive.Buckets = buckets
+}
+
+// setSkewed sets the SKEWED BY ... ON ... clause, single column values are kept as one-element tuples.
+func (v *HiveVisitor) setSkewed(ctx parser.ITableSkewedContext) {
+	skewed := &types.HiveSkewed{
+		Columns:             v.getColumnNames(ctx.GetSkewedCols()),
+		StoredAsDirectories: ctx.StoredAsDirs() != nil,
+	}
+	element := ctx.GetSkewedValues()
+	if element.SkewedColumnValues() != nil {
+		for _, value := range element.SkewedColumnValues().AllSkewedColumnValue() {
+			skewed.Values = append(skewed.Values, []string{decodeString(types.Hive, value.GetText())})
+		}
+	} else if element.SkewedColumnValuePairList() != nil {
+		for _, pair := range element.SkewedColumnValuePairList().AllSkewedColumnValuePair() {
+			var values []string
+			for _, value := range pair.GetColValues().AllSkewedColumnValue() {
+				values = append(values, decodeString(types.Hive, value.GetText()))
+			}
+			skewed.Values = append(skewed.Values, values)
+		}
+	}
+	v.Table.Hive.Skewed = skewed
+}
+
+// setRowFormat sets the ROW FORMAT SERDE or ROW FORMAT DELIMITED clause.
+func (v *HiveVisitor) setRowFormat(ctx parser.ITableRowFormatContext) {
+	rowFormat := &types.HiveRowFormat{}
+	if serde := ctx.RowFormatSerde(); serde != nil {
+		rowFormat.Serde = decodeString(types.Hive, serde.GetName().GetText())
+		if serde.GetSerdeprops() != nil {
+			v.Table.Hive.SerdeProperties = v.getProperties(serde.GetSerdeprops())
+		}
+	} else if delimited := ctx.RowFormatDelimited(); delimited != nil {
+		if fields := delimited.TableRowFormatFieldIdentifier(); fields != nil {
+			rowFormat.FieldsTerminatedBy = decodeString(types.Hive, fields.GetFldIdnt().GetText())
+			if fields.GetFldEscape() != nil {
+				rowFormat.EscapedBy = decodeString(types.Hive, fields.GetFldEscape().GetText())
+			}
+		}
+		if items := delimited.TableRowFormatCollItemsIdentifier(); items != nil {
+			rowFormat.CollectionItemsTerminatedBy = decodeString(types.Hive, items.GetCollIdnt().GetText())
+		}
+		if keys := delimited.TableRowFormatMapKeysIdentifier(); keys != nil {
+			rowFormat.MapKeysTerminatedBy = decodeString(types.Hive, keys.GetMapKeysIdnt().GetText())
+		}
+		if lines := delimited.TableRowFormatLinesIdentifier(); lines != nil {
+			rowFormat.LinesTerminatedBy = decodeString(types.Hive, lines.GetLinesIdnt().GetText())
+		}
+		if null := delimited.TableRowNullFormat(); null != nil {
+			rowFormat.NullDefinedAs = decodeString(types.Hive, null.GetNullIdnt().GetText())
+		}
+	}
+	v.Table.Hive.RowFormat = rowFormat
+}
+
+// setFileFormat sets the STORED AS / STORED BY clause, file formats are upper-cased, e.g. ORC, PARQUET.
+func (v *HiveVisitor) setFileFormat(ctx parser.ITableFileFormatContext) {
+	hive := v.Table.Hive
+	if ctx.GetInFmt() != nil {
+		hive.InputFormat = decodeString(types.Hive, ctx.GetInFmt().GetText())
+		hive.OutputFormat = decodeString(types.Hive, ctx.GetOutFmt().GetText())
+	}
+	if ctx.GetStorageHandler() != nil {
+		hive.StorageHandler = decodeString(types.Hive, ctx.GetStorageHandler().GetText())
+	} else if ctx.KW_BY() != nil && ctx.GetGenericSpec() != nil {
+		// STORED BY ICEBERG
+		hive.StorageHandler = strings.ToUpper(ctx.GetGenericSpec().GetText())
+	} else if ctx.GetGenericSpec() != nil {
+		hive.FileFormat = strings.ToUpper(ctx.GetGenericSpec().GetText())
+	}
+	if ctx.GetFileformat() != nil {
+		hive.FileFormat = strings.ToUpper(ctx.GetFileformat().GetText())
+	}
+	if ctx.GetSerdeprops() != nil {
+		hive.SerdeProperties = v.getProperties(ctx.GetSerdeprops())
+	}
+}
+
+// getProperties returns the key-value pairs of TBLPROPERTIES or SERDEPROPERTIES, keys without a value map to "".
+func (v *HiveVisitor) getProperties(ctx parser.ITablePropertiesContext) map[string]string {
+	properties := make(map[string]string)
+	list := ctx.TablePropertiesList()
+	for _, property := range list.AllKeyValueProperty() {
+		properties[decodeString(types.Hive, property.GetKey().GetText())] = decodeString(types.Hive, property.GetValue().GetText())
+	}
+	for _, property := range list.AllKeyProperty() {
+		properties[decodeString(types.Hive, property.GetKey().GetText())] = ""
+	}
+	return properties
+}
+
+func (v *HiveVisitor) getColumnNames(ctx parser.IColumnNameListContext) []string {
+	var names []string
+	for _, name := range ctx.AllColumnName() {
+		names = append(names, v.getColumnName(name))
+	}
+	return names
+}
+
+func (v *HiveVisitor) getColumnName(ctx parser.IColumnNameContext) string {
+	name, _ := normalizeIdentifier(types.Hive, ctx.GetText())
+	return name
+}
+
+// resolveTableName sets the database and table name, hive treats `db.table` in a single pair
+// of backticks (as printed by SHOW CREATE TABLE) as a qualified name, so the dots are split as is.
+// Database falls back to 'default' while Schema is only set when given.
+func (v *HiveVisitor) resolveTableName(tableName string) {
+	var parts []string
+	for _, part := range splitQualifiedName(tableName) {
+		name, quoted := normalizeIdentifier(types.Hive, part)
+		parts = append(parts, strings.Split(name, ".")...)
+		v.Table.Quoted = quoted
+	}
+	setQualifiedName(v.Table, parts)
+	if v.Table.Database == "" {
+		v.Table.Database = "default"
 	}
-	return database, table
 }
 
 // parseColumnType parses the column type definition and returns an AntlrColumn.
@@ -139,6 +375,9 @@ func (v *HiveVisitor) setColumnAttributes(column *types.AntlrColumn, originalTyp
 	switch originalType {
 	case "char", "varchar", "string":
 		column.StringLength = If(length > 0 && length < 50, length, 50)
+		column.CharLength = length
+		// hive strings are utf-8 encoded
+		resolveStringLength(column, "", 0)
 	case "tinyint":
 		column.MaxInteger = math.MaxInt8
 	case "smallint":
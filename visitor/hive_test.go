@@ -0,0 +1,27 @@
+package visitor
+
+import "testing"
+
+func TestParseHiveComment(t *testing.T) {
+	table, err := ParseHiveSql(`CREATE TABLE t (
+	a string COMMENT 'it\'s a "name"',
+	b int COMMENT "tab\there"
+) COMMENT 'line\nbreak'
+ROW FORMAT DELIMITED FIELDS TERMINATED BY '\t' LINES TERMINATED BY '\n'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Comment != "line\nbreak" {
+		t.Errorf("table comment = %q, want %q", table.Comment, "line\nbreak")
+	}
+	if c := table.Columns[0].Comment; c != `it's a "name"` {
+		t.Errorf("a: comment = %q, want %q", c, `it's a "name"`)
+	}
+	if c := table.Columns[1].Comment; c != "tab\there" {
+		t.Errorf("b: comment = %q, want %q", c, "tab\there")
+	}
+	if f := table.Hive.RowFormat; f == nil || f.FieldsTerminatedBy != "\t" || f.LinesTerminatedBy != "\n" {
+		t.Errorf("row format = %+v, want tab and newline terminators", f)
+	}
+}
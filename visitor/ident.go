@@ -0,0 +1,108 @@
+package visitor
+
+import (
+	"github.com/aierdong/createtable-sql-parser/types"
+	"strings"
+)
+
+// splitQualifiedName splits a dotted name into its parts. Dots inside "quoted", [bracketed]
+// or `backticked` parts are kept, the quotes themselves are not removed.
+func splitQualifiedName(name string) []string {
+	var parts []string
+	var part strings.Builder
+	var closing rune
+	runes := []rune(name)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case closing != 0:
+			part.WriteRune(r)
+			if r == closing {
+				if i+1 < len(runes) && runes[i+1] == closing { // escaped "" ]] ``
+					part.WriteRune(runes[i+1])
+					i++
+				} else {
+					closing = 0
+				}
+			}
+		case r == '"' || r == '`':
+			closing = r
+			part.WriteRune(r)
+		case r == '[':
+			closing = ']'
+			part.WriteRune(r)
+		case r == '.':
+			parts = append(parts, strings.TrimSpace(part.String()))
+			part.Reset()
+		default:
+			part.WriteRune(r)
+		}
+	}
+	return append(parts, strings.TrimSpace(part.String()))
+}
+
+// setQualifiedName fills Catalog, Schema and Name of the table from the parts of its name,
+// Database keeps the qualifier right before the name for backward compatibility.
+func setQualifiedName(table *types.AntlrTable, parts []string) {
+	n := len(parts)
+	table.Name = parts[n-1]
+	if n >= 2 {
+		table.Schema = parts[n-2]
+		table.Database = parts[n-2]
+	}
+	if n >= 3 {
+		table.Catalog = parts[n-3]
+	}
+}
+
+// normalizeIdentifier removes the quotes around an identifier and un-escapes the doubled
+// quote characters inside, e.g. "a""b" or [a]]b]. Unquoted identifiers are folded the way
+// the dialect stores them: upper case for oracle, lower case for postgres. Hive lower cases
+// all identifiers. It also reports whether the identifier was quoted.
+func normalizeIdentifier(dialect types.Dialect, ident string) (string, bool) {
+	ident = strings.TrimSpace(ident)
+	if n := len(ident); n >= 2 {
+		var closing byte
+		switch ident[0] {
+		case '"', '`':
+			closing = ident[0]
+		case '[':
+			closing = ']'
+		}
+		if closing != 0 && ident[n-1] == closing {
+			name := strings.ReplaceAll(ident[1:n-1], string([]byte{closing, closing}), string(closing))
+			if dialect == types.Hive {
+				name = strings.ToLower(name)
+			}
+			return name, true
+		}
+	}
+	switch dialect {
+	case types.PostgreSQL, types.KingbaseES, types.Hive:
+		return strings.ToLower(ident), false
+	case types.Oracle, types.Dameng:
+		return strings.ToUpper(ident), false
+	}
+	return ident, false
+}
+
+// normalizeQualifiedName splits a dotted name and normalizes each part,
+// it reports whether the last part was quoted.
+func normalizeQualifiedName(dialect types.Dialect, name string) (parts []string, quoted bool) {
+	parts = splitQualifiedName(name)
+	for i := range parts {
+		parts[i], quoted = normalizeIdentifier(dialect, parts[i])
+	}
+	return parts, quoted
+}
+
+// identifierEqual reports whether two normalized identifiers name the same object. Postgres
+// and oracle compare exactly since unquoted names are already folded, the other dialects
+// compare case-insensitively.
+func identifierEqual(dialect types.Dialect, a, b string) bool {
+	switch dialect {
+	case types.PostgreSQL, types.Oracle, types.KingbaseES, types.Dameng:
+		return a == b
+	}
+	return strings.EqualFold(a, b)
+}
@@ -0,0 +1,89 @@
+package visitor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aierdong/createtable-sql-parser/types"
+)
+
+func TestNormalizeIdentifier(t *testing.T) {
+	tests := []struct {
+		dialect types.Dialect
+		ident   string
+		want    string
+		quoted  bool
+	}{
+		{types.PostgreSQL, "MyTable", "mytable", false},
+		{types.PostgreSQL, `"MyTable"`, "MyTable", true},
+		{types.PostgreSQL, `"a""b"`, `a"b`, true},
+		{types.KingbaseES, "MyTable", "mytable", false},
+		{types.Oracle, "my_table", "MY_TABLE", false},
+		{types.Oracle, `"my_table"`, "my_table", true},
+		{types.Dameng, "my_table", "MY_TABLE", false},
+		{types.MySQL, "MyTable", "MyTable", false},
+		{types.MySQL, "`a``b`", "a`b", true},
+		{types.MySQL, `"a"`, "a", true},
+		{types.SQLServer, "[a]]b]", "a]b", true},
+		{types.SQLServer, `"My Table"`, "My Table", true},
+		{types.SQLServer, "MyTable", "MyTable", false},
+		{types.SQLite3, "[a b]", "a b", true},
+		{types.SQLite3, "`a`", "a", true},
+		{types.Hive, "MyTable", "mytable", false},
+		{types.Hive, "`MyTable`", "mytable", true},
+		{types.MySQL, " `a` ", "a", true},
+		{types.MySQL, "`", "`", false},
+		{types.SQLServer, "[a", "[a", false},
+	}
+	for _, tt := range tests {
+		got, quoted := normalizeIdentifier(tt.dialect, tt.ident)
+		if got != tt.want || quoted != tt.quoted {
+			t.Errorf("normalizeIdentifier(%s, %q) = %q, %v, want %q, %v", tt.dialect, tt.ident, got, quoted, tt.want, tt.quoted)
+		}
+	}
+}
+
+func TestNormalizeQualifiedName(t *testing.T) {
+	tests := []struct {
+		dialect types.Dialect
+		name    string
+		want    []string
+		quoted  bool
+	}{
+		{types.SQLServer, `[my.db].dbo."x""y.z"`, []string{"my.db", "dbo", `x"y.z`}, true},
+		{types.PostgreSQL, `Public."Orders"`, []string{"public", "Orders"}, true},
+		{types.PostgreSQL, `"Public".Orders`, []string{"Public", "orders"}, false},
+		{types.Oracle, `hr . employees`, []string{"HR", "EMPLOYEES"}, false},
+		{types.MySQL, "`a.b`.`c`", []string{"a.b", "c"}, true},
+		{types.Hive, "db.T", []string{"db", "t"}, false},
+	}
+	for _, tt := range tests {
+		got, quoted := normalizeQualifiedName(tt.dialect, tt.name)
+		if !reflect.DeepEqual(got, tt.want) || quoted != tt.quoted {
+			t.Errorf("normalizeQualifiedName(%s, %q) = %q, %v, want %q, %v", tt.dialect, tt.name, got, quoted, tt.want, tt.quoted)
+		}
+	}
+}
+
+func TestIdentifierEqual(t *testing.T) {
+	tests := []struct {
+		dialect types.Dialect
+		a, b    string
+		want    bool
+	}{
+		{types.PostgreSQL, "orders", "orders", true},
+		{types.PostgreSQL, "Orders", "orders", false},
+		{types.KingbaseES, "Orders", "orders", false},
+		{types.Oracle, "ORDERS", "orders", false},
+		{types.Dameng, "ORDERS", "ORDERS", true},
+		{types.MySQL, "Orders", "orders", true},
+		{types.SQLServer, "Orders", "ORDERS", true},
+		{types.SQLite3, "Orders", "orders", true},
+		{types.Hive, "orders", "orders", true},
+	}
+	for _, tt := range tests {
+		if got := identifierEqual(tt.dialect, tt.a, tt.b); got != tt.want {
+			t.Errorf("identifierEqual(%s, %q, %q) = %v, want %v", tt.dialect, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package visitor
+
+import "github.com/aierdong/createtable-sql-parser/types"
+
+// ParseKingbaseSql parses the DDL of KingbaseES, which is postgres compatible and adds oracle
+// and mysql compatible types, e.g. VARCHAR2, NUMBER and TINYINT.
+func ParseKingbaseSql(sql string, opts ...Option) (*types.AntlrTable, error) {
+	return parsePgScript(sql, types.KingbaseES, opts)
+}
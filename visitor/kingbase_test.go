@@ -0,0 +1,61 @@
+package visitor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aierdong/createtable-sql-parser/types"
+)
+
+func TestParseKingbaseSql(t *testing.T) {
+	sql := `CREATE TABLE public.t (
+	id sys_catalog.int4 NOT NULL,
+	a varchar2(20),
+	b number(10,2),
+	c tinyint,
+	d clob,
+	e blob,
+	f datetime,
+	g text
+)`
+	table, err := ParseKingbaseSql(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.Dialect != types.KingbaseES || table.Schema != "public" || table.Name != "t" {
+		t.Errorf("table = %s %s.%s, want kingbase public.t", table.Dialect, table.Schema, table.Name)
+	}
+
+	tests := []struct {
+		name         string
+		dataType     types.DbType
+		maxInteger   int64
+		maxFloat     float64
+		stringLength int
+	}{
+		{"id", types.Integer, math.MaxInt32, 0, 0},
+		{"a", types.String, 0, 0, 20},
+		{"b", types.Numeric, 0, getMaxFloat64(10), 0},
+		{"c", types.Integer, math.MaxInt8, 0, 0},
+		{"d", types.String, 0, 0, 50},
+		{"e", types.Binary, 0, 0, 50},
+		{"f", types.DateTime, 0, 0, 0},
+		{"g", types.String, 0, 0, 50},
+	}
+	if len(table.Columns) != len(tests) {
+		t.Fatalf("columns = %d, want %d", len(table.Columns), len(tests))
+	}
+	for i, tt := range tests {
+		c := table.Columns[i]
+		if c.Name != tt.name || c.DataType != tt.dataType || c.MaxInteger != tt.maxInteger ||
+			c.MaxFloat != tt.maxFloat || c.StringLength != tt.stringLength {
+			t.Errorf("%s: DataType = %s, MaxInteger = %d, MaxFloat = %g, StringLength = %d, want %s, %d, %g, %d",
+				c.Name, c.DataType, c.MaxInteger, c.MaxFloat, c.StringLength, tt.dataType, tt.maxInteger, tt.maxFloat, tt.stringLength)
+		}
+	}
+
+	// the oracle compatible types are not postgres types
+	if _, err := ParsePgSql(sql); err == nil {
+		t.Error("ParsePgSql accepted varchar2, want an unsupported data type error")
+	}
+}
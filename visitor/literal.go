@@ -0,0 +1,261 @@
+package visitor
+
+import (
+	"github.com/aierdong/createtable-sql-parser/types"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+var (
+	dollarQuoteRegexp = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+	charsetIntroducer = regexp.MustCompile(`^_[A-Za-z0-9]+`)
+	uescapeRegexp     = regexp.MustCompile(`(?i)^\s*UESCAPE\s*'([^'])'`)
+)
+
+// decodeString returns the value of a string literal of the dialect, adjacent literals
+// separated by white spaces are concatenated. Text that is not a string literal is returned as is.
+//
+// Supported forms: doubled quotes everywhere, N'...' national strings, mysql and hive
+// "double quoted" strings and backslash escapes, mysql _charset introducers, pg E'...',
+// U&'...' and $tag$...$tag$ strings, oracle q'[...]' strings.
+func decodeString(dialect types.Dialect, text string) string {
+	text = strings.TrimSpace(text)
+	var sb strings.Builder
+	for i := 0; text != ""; i++ {
+		value, rest, ok := decodeLiteral(dialect, text)
+		if !ok {
+			if i == 0 {
+				return text
+			}
+			break
+		}
+		sb.WriteString(value)
+		text = strings.TrimSpace(rest)
+	}
+	return sb.String()
+}
+
+// decodeLiteral decodes the string literal at the beginning of text and returns the rest of text.
+func decodeLiteral(dialect types.Dialect, text string) (value string, rest string, ok bool) {
+	if dialect == types.PostgreSQL {
+		if tag := dollarQuoteRegexp.FindString(text); tag != "" {
+			end := strings.Index(text[len(tag):], tag)
+			if end < 0 {
+				return "", "", false
+			}
+			return text[len(tag) : len(tag)+end], text[len(tag)+end+len(tag):], true
+		}
+	}
+
+	escapes := dialect == types.MySQL || dialect == types.Hive
+	switch {
+	case dialect == types.MySQL && charsetIntroducer.MatchString(text):
+		text = text[len(charsetIntroducer.FindString(text)):]
+	case dialect == types.PostgreSQL && len(text) > 2 && strings.EqualFold(text[:2], "u&"):
+		return decodeUnicodeLiteral(text[2:])
+	case dialect == types.PostgreSQL && len(text) > 1 && (text[0] == 'e' || text[0] == 'E'):
+		text = text[1:]
+		escapes = true
+	case len(text) > 1 && (text[0] == 'n' || text[0] == 'N'):
+		text = text[1:]
+	}
+
+	if dialect == types.Oracle && len(text) > 3 && (text[0] == 'q' || text[0] == 'Q') && text[1] == '\'' {
+		return decodeOracleQuote(text)
+	}
+	if len(text) < 2 || !(text[0] == '\'' || (text[0] == '"' && (dialect == types.MySQL || dialect == types.Hive))) {
+		return "", "", false
+	}
+
+	quote := text[0]
+	var sb strings.Builder
+	for i := 1; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == quote && i+1 < len(text) && text[i+1] == quote:
+			sb.WriteByte(quote)
+			i++
+		case c == quote:
+			return sb.String(), text[i+1:], true
+		case c == '\\' && escapes && i+1 < len(text):
+			i += decodeEscape(dialect, text[i+1:], &sb)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", "", false
+}
+
+// decodeUnicodeLiteral decodes the pg U&'...' string following the U& prefix, with its optional
+// UESCAPE clause. \XXXX and \+XXXXXX are code points, the escape character doubled is itself.
+// A utf-16 surrogate pair is written as two escapes, an unpaired surrogate is invalid as in pg.
+func decodeUnicodeLiteral(text string) (value string, rest string, ok bool) {
+	value, rest, ok = decodeLiteral(types.PostgreSQL, text)
+	if !ok {
+		return "", "", false
+	}
+	escape := byte('\\')
+	if m := uescapeRegexp.FindStringSubmatch(rest); m != nil {
+		escape = m[1][0]
+		rest = rest[len(m[0]):]
+	}
+
+	var sb strings.Builder
+	var high rune // pending high surrogate
+	for i := 0; i < len(value); i++ {
+		doubled := value[i] == escape && i+1 < len(value) && value[i+1] == escape
+		if high != 0 && (value[i] != escape || doubled) {
+			return "", "", false // the low surrogate must follow as an escape
+		}
+		if value[i] != escape {
+			sb.WriteByte(value[i])
+			continue
+		}
+		if doubled {
+			sb.WriteByte(escape)
+			i++
+			continue
+		}
+		digits, start := 4, i+1
+		if start < len(value) && value[start] == '+' {
+			digits, start = 6, start+1
+		}
+		if start+digits > len(value) {
+			return "", "", false
+		}
+		n, err := strconv.ParseUint(value[start:start+digits], 16, 32)
+		if err != nil {
+			return "", "", false
+		}
+		r := rune(n)
+		switch {
+		case r > unicode.MaxRune:
+			return "", "", false
+		case high != 0:
+			if r < 0xdc00 || r > 0xdfff {
+				return "", "", false
+			}
+			sb.WriteRune(utf16.DecodeRune(high, r))
+			high = 0
+		case r >= 0xd800 && r < 0xdc00:
+			high = r
+		case utf16.IsSurrogate(r): // a low surrogate without a high one
+			return "", "", false
+		default:
+			sb.WriteRune(r)
+		}
+		i = start + digits - 1
+	}
+	if high != 0 {
+		return "", "", false
+	}
+	return sb.String(), rest, true
+}
+
+// decodeEscape writes the character of the backslash escape at the beginning of s,
+// and returns the number of bytes consumed.
+func decodeEscape(dialect types.Dialect, s string, sb *strings.Builder) int {
+	switch s[0] {
+	case '0':
+		if dialect == types.MySQL || len(s) < 3 || !isOctal(s[1]) {
+			sb.WriteByte(0)
+			return 1
+		}
+	case 'b':
+		sb.WriteByte('\b')
+		return 1
+	case 'f':
+		if dialect != types.MySQL {
+			sb.WriteByte('\f')
+			return 1
+		}
+	case 'n':
+		sb.WriteByte('\n')
+		return 1
+	case 'r':
+		sb.WriteByte('\r')
+		return 1
+	case 't':
+		sb.WriteByte('\t')
+		return 1
+	case 'Z':
+		if dialect == types.MySQL {
+			sb.WriteByte(0x1a)
+			return 1
+		}
+	case '%', '_':
+		if dialect == types.MySQL { // kept for LIKE patterns
+			sb.WriteByte('\\')
+		}
+	}
+
+	if dialect != types.MySQL {
+		if n, size := parseNumericEscape(s); size > 0 {
+			sb.WriteRune(rune(n))
+			return size
+		}
+	}
+	sb.WriteByte(s[0])
+	return 1
+}
+
+// parseNumericEscape parses \xHH, \uXXXX, \UXXXXXXXX and octal \ooo escapes without the backslash.
+func parseNumericEscape(s string) (int64, int) {
+	prefix, base, maxDigits := 1, 16, 0
+	switch s[0] {
+	case 'x':
+		maxDigits = 2
+	case 'u':
+		maxDigits = 4
+	case 'U':
+		maxDigits = 8
+	default:
+		prefix, base, maxDigits = 0, 8, 3
+	}
+	digits := 0
+	for digits < maxDigits && prefix+digits < len(s) && isDigitOf(s[prefix+digits], base) {
+		digits++
+	}
+	if digits == 0 {
+		return 0, 0
+	}
+	n, err := strconv.ParseInt(s[prefix:prefix+digits], base, 64)
+	if err != nil {
+		return 0, 0
+	}
+	return n, prefix + digits
+}
+
+// decodeOracleQuote decodes oracle alternative quoting, q'[...]', q'{...}', q'!...!'.
+func decodeOracleQuote(text string) (string, string, bool) {
+	closing := text[2]
+	switch closing {
+	case '[':
+		closing = ']'
+	case '{':
+		closing = '}'
+	case '(':
+		closing = ')'
+	case '<':
+		closing = '>'
+	}
+	end := strings.Index(text[3:], string([]byte{closing, '\''}))
+	if end < 0 {
+		return "", "", false
+	}
+	return text[3 : 3+end], text[3+end+2:], true
+}
+
+func isOctal(c byte) bool {
+	return c >= '0' && c <= '7'
+}
+
+func isDigitOf(c byte, base int) bool {
+	if base == 8 {
+		return isOctal(c)
+	}
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
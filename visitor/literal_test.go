@@ -0,0 +1,130 @@
+package visitor
+
+import (
+	"testing"
+
+	"github.com/aierdong/createtable-sql-parser/types"
+)
+
+func TestDecodeString(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect types.Dialect
+		text    string
+		want    string
+	}{
+		{"doubled quote", types.SQLServer, `'it''s'`, "it's"},
+		{"national", types.SQLServer, `N'Name'`, "Name"},
+		{"national lower case", types.Oracle, `n'Name'`, "Name"},
+		{"no backslash escapes", types.SQLServer, `'a\nb'`, `a\nb`},
+		{"mysql escapes", types.MySQL, `'it\'s\ta\nb\\c\Z'`, "it's\ta\nb\\c\x1a"},
+		{"mysql like escapes", types.MySQL, `'100\% \_'`, `100\% \_`},
+		{"mysql unknown escape", types.MySQL, `'\q\0'`, "q\x00"},
+		{"mysql double quoted", types.MySQL, `"say ""hi"" 'x'"`, `say "hi" 'x'`},
+		{"mysql charset introducer", types.MySQL, `_utf8mb4'abc'`, "abc"},
+		{"mysql adjacent literals", types.MySQL, `'a' "b"  'c'`, "abc"},
+		{"hive escapes", types.Hive, `"tab\there\u0041"`, "tab\thereA"},
+		{"pg standard string", types.PostgreSQL, `'C:\dir'`, `C:\dir`},
+		{"pg escape string", types.PostgreSQL, `E'it\'s\n\x41\101\u00e9'`, "it's\nAAé"},
+		{"pg unicode string", types.PostgreSQL, `U&'d\0061t\+000061'`, "data"},
+		{"pg dollar quoted", types.PostgreSQL, `$$it's $1$$`, "it's $1"},
+		{"pg tagged dollar quoted", types.PostgreSQL, `$body$a $$ b$body$`, "a $$ b"},
+		{"pg adjacent literals", types.PostgreSQL, "'a'\n'b'", "ab"},
+		{"oracle brackets", types.Oracle, `q'[it's]'`, "it's"},
+		{"oracle braces", types.Oracle, `Q'{a}b}'`, "a}b"},
+		{"oracle same delimiter", types.Oracle, `q'!x'y!'`, "x'y"},
+		{"oracle national q", types.Oracle, `nq'<a>'`, "a"},
+		{"not a literal", types.MySQL, "NULL", "NULL"},
+		{"double quoted identifier", types.PostgreSQL, `"name"`, `"name"`},
+		{"unterminated", types.SQLServer, `'abc`, `'abc`},
+		{"trailing text", types.SQLServer, `'a' + 'b'`, "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeString(tt.dialect, tt.text); got != tt.want {
+				t.Errorf("decodeString(%s, %q) = %q, want %q", tt.dialect, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeLiteral(t *testing.T) {
+	tests := []struct {
+		dialect types.Dialect
+		text    string
+		value   string
+		rest    string
+		ok      bool
+	}{
+		{types.MySQL, `'a''b' COMMENT`, "a'b", " COMMENT", true},
+		{types.PostgreSQL, `$x$a$x$::text`, "a", "::text", true},
+		{types.PostgreSQL, `$x$a$y$`, "", "", false},
+		{types.PostgreSQL, `U&'!0041' UESCAPE '!' x`, "A", " x", true},
+		{types.Oracle, `q'(a)' || b`, "a", " || b", true},
+		{types.Oracle, `q'(a'`, "", "", false},
+		{types.SQLServer, `"a"`, "", "", false},
+		{types.SQLServer, `N`, "", "", false},
+	}
+	for _, tt := range tests {
+		value, rest, ok := decodeLiteral(tt.dialect, tt.text)
+		if value != tt.value || rest != tt.rest || ok != tt.ok {
+			t.Errorf("decodeLiteral(%s, %q) = %q, %q, %v, want %q, %q, %v", tt.dialect, tt.text, value, rest, ok, tt.value, tt.rest, tt.ok)
+		}
+	}
+}
+
+func TestDecodeUnicodeLiteral(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+		ok   bool
+	}{
+		{name: "code points", text: `'d\0061t\+000061'`, want: "data", ok: true},
+		{name: "doubled escape", text: `'a\\b'`, want: `a\b`, ok: true},
+		{name: "uescape", text: `'d!0061t!!' UESCAPE '!'`, want: "dat!", ok: true},
+		{name: "surrogate pair", text: `'\D83D\DE00x'`, want: "😀x", ok: true},
+		{name: "high surrogate before a character", text: `'\D83Dx\0041'`},
+		{name: "high surrogate before a doubled escape", text: `'\D83D\\'`},
+		{name: "high surrogate before a code point", text: `'\D83D\0041'`},
+		{name: "high surrogate at the end", text: `'a\D83D'`},
+		{name: "lone low surrogate", text: `'\DE00'`},
+		{name: "beyond unicode", text: `'\+110000'`},
+		{name: "short escape", text: `'\00'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, _, ok := decodeUnicodeLiteral(tt.text)
+			if ok != tt.ok || value != tt.want {
+				t.Errorf("decodeUnicodeLiteral(%q) = %q, %v, want %q, %v", tt.text, value, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	// an invalid literal is kept as written
+	if got := decodeString(types.PostgreSQL, `U&'\D83Dx\0041'`); got != `U&'\D83Dx\0041'` {
+		t.Errorf("decodeString of an unpaired surrogate = %q, want the text as is", got)
+	}
+}
+
+func TestDecodeOracleQuote(t *testing.T) {
+	tests := []struct {
+		text  string
+		value string
+		rest  string
+		ok    bool
+	}{
+		{`q'[a]b]'`, "a]b", "", true},
+		{`q'{a}'x`, "a", "x", true},
+		{`q'(a))'`, "a)", "", true},
+		{`q'<'a'>'`, "'a'", "", true},
+		{`q'#a#'`, "a", "", true},
+		{`q'[a'`, "", "", false},
+	}
+	for _, tt := range tests {
+		value, rest, ok := decodeOracleQuote(tt.text)
+		if value != tt.value || rest != tt.rest || ok != tt.ok {
+			t.Errorf("decodeOracleQuote(%q) = %q, %q, %v, want %q, %q, %v", tt.text, value, rest, ok, tt.value, tt.rest, tt.ok)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/aierdong/createtable-sql-parser/types"
 	"github.com/antlr4-go/antlr/v4"
 	"math"
-	"regexp"
 	"strconv"
 	"strings"
 )
@@ -16,9 +15,11 @@ type MySQLVisitor struct {
 	*parser.BaseMySQLParserVisitor
 	Table *types.AntlrTable
 	Err   error
+
+	comments *antlr.CommonTokenStream // set when inline comments are used
 }
 
-func ParseMySql(sql string) (table *types.AntlrTable, err error) {
+func ParseMySql(sql string, opts ...Option) (table *types.AntlrTable, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			table = nil
@@ -26,6 +27,19 @@ func ParseMySql(sql string) (table *types.AntlrTable, err error) {
 		}
 	}()
 
+	o := newOptions(opts)
+
+	// a script, e.g. mysqldump output, is parsed from its first CREATE TABLE statement,
+	// the SET, DROP TABLE, LOCK TABLES, INSERT and trigger statements are skipped
+	sql = resolveMySQLVersionComments(sql, o.mysqlVersion)
+	for _, s := range splitMySQLStatements(sql) {
+		head := statementHead(s)
+		if strings.HasPrefix(head, "CREATE TABLE") || strings.HasPrefix(head, "CREATE TEMPORARY TABLE") {
+			sql = s
+			break
+		}
+	}
+
 	lexer := parser.NewMySQLLexer(antlr.NewInputStream(sql))
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 
@@ -39,8 +53,12 @@ func ParseMySql(sql string) (table *types.AntlrTable, err error) {
 		Table: &types.AntlrTable{
 			Dialect: types.MySQL,
 			Columns: make([]*types.AntlrColumn, 0),
+			MySQL:   &types.MySQLTable{},
 		},
 	}
+	if o.inlineComments {
+		visitor.comments = stream
+	}
 	tree.Accept(visitor)
 
 	if visitor.Err != nil {
@@ -63,11 +81,9 @@ func (v *MySQLVisitor) VisitCreateTable(ctx *parser.CreateTableContext) interfac
 		return nil
 	}
 
-	arr := strings.Split(ctx.TableName().GetText(), ".")
-	if len(arr) == 2 {
-		v.Table.Database = strings.Trim(arr[0], "`")
-	}
-	v.Table.Name = strings.Trim(arr[len(arr)-1], "`")
+	parts, quoted := normalizeQualifiedName(types.MySQL, ctx.TableName().GetText())
+	setQualifiedName(v.Table, parts)
+	v.Table.Quoted = quoted
 
 	if ctx.TableElementList() == nil {
 		v.Err = fmt.Errorf("table element list is nil")
@@ -78,6 +94,13 @@ func (v *MySQLVisitor) VisitCreateTable(ctx *parser.CreateTableContext) interfac
 	if ctx.CreateTableOptions() != nil {
 		ctx.CreateTableOptions().Accept(v)
 	}
+	if ctx.PartitionClause() != nil {
+		v.setPartitioning(ctx.PartitionClause())
+	}
+	if v.Table.Comment == "" {
+		v.Table.Comment = inlineTableComment(v.comments, ctx.GetParent().(antlr.ParserRuleContext), ctx.OPEN_PAR_SYMBOL())
+	}
+	v.resolveCharset()
 
 	return nil
 }
@@ -93,61 +116,392 @@ func (v *MySQLVisitor) VisitTableElementList(ctx *parser.TableElementListContext
 			return nil
 		}
 
-		dataType := v.getDataType(colDef)
-
+		fieldDef := colDef.FieldDefinition()
 		// dataType: integer, string..., and length, scala
-		column, err := v.parseColumnType(dataType)
+		column, err := v.parseColumnType(fieldDef.DataType())
 		if err != nil {
 			v.Err = err
 			return nil
 		}
+		column.Name, column.Quoted = normalizeIdentifier(types.MySQL, colDef.ColumnName().GetText())
 
-		// column comment
-		for _, att := range colDef.FieldDefinition().AllColumnAttribute() {
-			if att.COMMENT_SYMBOL() != nil && att.TextLiteral() != nil {
-				column.Comment = strings.Trim(att.TextLiteral().GetText(), "'")
-			}
-			if att.AUTO_INCREMENT_SYMBOL() != nil {
-				column.AutoIncrement = true
+		if fieldDef.DataType().SERIAL_SYMBOL() != nil {
+			// SERIAL is BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE
+			v.addColumnConstraint(column, types.Unique, "")
+		}
+		if fieldDef.Collate() != nil {
+			column.Collation = mysqlName(fieldDef.Collate().CollationName().GetText())
+		}
+		if fieldDef.AS_SYMBOL() != nil && fieldDef.ExprWithParentheses() != nil {
+			column.Generated = &types.Generated{
+				Expression: getOriginalText(fieldDef.ExprWithParentheses().Expr()),
+				Stored:     fieldDef.STORED_SYMBOL() != nil,
 			}
 		}
 
-		v.Table.Columns = append(v.Table.Columns, &types.AntlrColumn{
-			Name:          strings.Trim(colDef.ColumnName().GetText(), "`"),
-			DataType:      column.DataType,
-			StringLength:  column.StringLength,
-			Scale:         column.Scale,
-			Comment:       column.Comment,
-			AutoIncrement: column.AutoIncrement,
-		})
+		for _, att := range fieldDef.AllColumnAttribute() {
+			v.setColumnAttribute(column, att)
+		}
+		for _, att := range fieldDef.AllGcolAttribute() {
+			v.setGcolAttribute(column, att)
+		}
+		if column.Comment == "" {
+			column.Comment = inlineComment(v.comments, colDef)
+		}
+
+		v.Table.Columns = append(v.Table.Columns, column)
 	}
 	return nil
 }
 
-func (v *MySQLVisitor) getDataType(colDef parser.IColumnDefinitionContext) string {
-	dataType := colDef.FieldDefinition().DataType()
-	dataTypeStr := dataType.GetText()
-	if dataType.FieldOptions() != nil {
-		fopt := dataType.FieldOptions().GetText()
-		if strings.HasSuffix(dataTypeStr, fopt) {
-			dataTypeStr = strings.TrimSuffix(dataTypeStr, fopt)
+// setColumnAttribute applies a column attribute: nullability, default, on update, visibility,
+// keys, check, comment, collation, auto increment and the mysql storage options.
+func (v *MySQLVisitor) setColumnAttribute(column *types.AntlrColumn, ctx parser.IColumnAttributeContext) {
+	switch {
+	case ctx.NullLiteral() != nil:
+		column.NotNull = ctx.NOT_SYMBOL() != nil
+	case ctx.INVISIBLE_SYMBOL() != nil:
+		column.Invisible = true
+	case ctx.AUTO_INCREMENT_SYMBOL() != nil || ctx.SERIAL_SYMBOL() != nil:
+		column.AutoIncrement = true
+		column.Identity = newIdentity(true)
+		if ctx.SERIAL_SYMBOL() != nil {
+			// SERIAL DEFAULT VALUE is NOT NULL AUTO_INCREMENT UNIQUE
+			column.NotNull = true
+			v.addColumnConstraint(column, types.Unique, "")
+		}
+	case ctx.DEFAULT_SYMBOL() != nil:
+		column.Default = getTextAfter(ctx, ctx.DEFAULT_SYMBOL().GetSymbol())
+	case ctx.UPDATE_SYMBOL() != nil:
+		column.OnUpdate = getTextAfter(ctx, ctx.UPDATE_SYMBOL().GetSymbol())
+	case ctx.UNIQUE_SYMBOL() != nil:
+		v.addColumnConstraint(column, types.Unique, "")
+	case ctx.KEY_SYMBOL() != nil:
+		column.NotNull = true
+		v.addColumnConstraint(column, types.PrimaryKey, "")
+	case ctx.COMMENT_SYMBOL() != nil && ctx.TextLiteral() != nil:
+		column.Comment = v.getText(ctx.TextLiteral())
+	case ctx.Collate() != nil && ctx.Collate().CollationName() != nil:
+		column.Collation = mysqlName(ctx.Collate().CollationName().GetText())
+	case ctx.ColumnFormat() != nil:
+		v.mysqlColumn(column).ColumnFormat = strings.ToUpper(ctx.ColumnFormat().GetText())
+	case ctx.StorageMedia() != nil:
+		v.mysqlColumn(column).Storage = strings.ToUpper(ctx.StorageMedia().GetText())
+	case ctx.SRID_SYMBOL() != nil && ctx.Real_ulonglong_number() != nil:
+		v.mysqlColumn(column).SRID, _ = strconv.ParseInt(ctx.Real_ulonglong_number().GetText(), 0, 64)
+	case ctx.CheckConstraint() != nil && ctx.CheckConstraint().ExprWithParentheses() != nil:
+		name := ""
+		if ctx.ConstraintName() != nil && ctx.ConstraintName().Identifier() != nil {
+			name, _ = normalizeIdentifier(types.MySQL, ctx.ConstraintName().Identifier().GetText())
 		}
+		constraint := v.addColumnConstraint(column, types.Check, name)
+		constraint.Check = getOriginalText(ctx.CheckConstraint().ExprWithParentheses().Expr())
+	}
+}
+
+// setGcolAttribute applies an attribute of a generated column in the pre 8.0 syntax.
+func (v *MySQLVisitor) setGcolAttribute(column *types.AntlrColumn, ctx parser.IGcolAttributeContext) {
+	switch {
+	case ctx.UNIQUE_SYMBOL() != nil:
+		v.addColumnConstraint(column, types.Unique, "")
+	case ctx.KEY_SYMBOL() != nil:
+		column.NotNull = true
+		v.addColumnConstraint(column, types.PrimaryKey, "")
+	case ctx.COMMENT_SYMBOL() != nil && ctx.TextString() != nil:
+		column.Comment = decodeString(types.MySQL, ctx.TextString().GetText())
+	case ctx.NULL_SYMBOL() != nil:
+		column.NotNull = ctx.NotRule() != nil
+	}
+}
+
+// addColumnConstraint adds a constraint on the single column to the table and returns it.
+func (v *MySQLVisitor) addColumnConstraint(column *types.AntlrColumn, typ types.ConstraintType, name string) *types.Constraint {
+	constraint := &types.Constraint{Name: name, Type: typ, Columns: []string{column.Name}}
+	v.Table.Constraints = append(v.Table.Constraints, constraint)
+	return constraint
+}
+
+// mysqlColumn returns the mysql specific attributes of the column, creating them if needed.
+func (v *MySQLVisitor) mysqlColumn(column *types.AntlrColumn) *types.MySQLColumn {
+	if column.MySQL == nil {
+		column.MySQL = &types.MySQLColumn{}
+	}
+	return column.MySQL
+}
+
+// getText decodes a text literal, which may be several adjacent strings.
+func (v *MySQLVisitor) getText(ctx parser.ITextLiteralContext) string {
+	var sb strings.Builder
+	if ctx.NCHAR_TEXT() != nil {
+		sb.WriteString(decodeString(types.MySQL, ctx.NCHAR_TEXT().GetText()))
+	}
+	for _, s := range ctx.AllTextStringLiteral() {
+		sb.WriteString(decodeString(types.MySQL, s.GetText()))
+	}
+	return sb.String()
+}
+
+// getCharset returns the charset of CHARACTER SET / ASCII / UNICODE / BYTE after a string datatype.
+func (v *MySQLVisitor) getCharset(ctx parser.ICharsetWithOptBinaryContext) string {
+	switch {
+	case ctx.Ascii() != nil:
+		return "latin1"
+	case ctx.Unicode() != nil:
+		return "ucs2"
+	case ctx.BYTE_SYMBOL() != nil:
+		return "binary"
+	case ctx.CharsetName() != nil:
+		return mysqlCharset(ctx.CharsetName().GetText())
+	}
+	return ""
+}
+
+// resolveCharset falls back to the table default charset and collation for string columns
+// without their own, then sets the byte length from the effective charset.
+func (v *MySQLVisitor) resolveCharset() {
+	if v.Table.Charset == "" && v.Table.Collation != "" {
+		v.Table.Charset = mysqlCollationCharset(v.Table.Collation)
+	}
+	for _, col := range v.Table.Columns {
+		if col.DataType != types.String && col.DataType != types.Char {
+			continue
+		}
+		if col.Charset == "" && col.Collation != "" {
+			col.Charset = mysqlCollationCharset(col.Collation)
+		}
+		if col.Charset == "" {
+			col.Charset = v.Table.Charset
+			col.Collation = v.Table.Collation
+		}
+		if col.Charset == "" {
+			col.Charset = "utf8mb4"
+		}
+		resolveStringLength(col, col.Charset, 0)
 	}
-	return dataTypeStr
 }
 
 func (v *MySQLVisitor) VisitCreateTableOptions(ctx *parser.CreateTableOptionsContext) interface{} {
 	for _, child := range ctx.AllCreateTableOption() {
 		if child.COMMENT_SYMBOL() != nil && child.TextStringLiteral() != nil {
-			v.Table.Comment = strings.Trim(child.TextStringLiteral().GetText(), "'")
-			return nil
+			v.Table.Comment = decodeString(types.MySQL, child.TextStringLiteral().GetText())
+			continue
+		}
+		if child.DefaultCharset() != nil && child.DefaultCharset().CharsetName() != nil {
+			v.Table.Charset = mysqlCharset(child.DefaultCharset().CharsetName().GetText())
+			continue
+		}
+		if child.DefaultCollation() != nil && child.DefaultCollation().CollationName() != nil {
+			v.Table.Collation = mysqlName(child.DefaultCollation().CollationName().GetText())
+			continue
+		}
+		if child.AUTO_INCREMENT_SYMBOL() != nil && child.Ulonglong_number() != nil {
+			seed, err := strconv.ParseInt(child.Ulonglong_number().GetText(), 10, 64)
+			if err != nil {
+				v.Err = fmt.Errorf("invalid auto_increment value: %s", child.Ulonglong_number().GetText())
+				return nil
+			}
+			v.Table.MySQL.AutoIncrement = seed
+			for _, col := range v.Table.Columns {
+				if col.Identity != nil {
+					col.Identity.Seed = seed
+				}
+			}
+			continue
+		}
+		if child.ENGINE_SYMBOL() != nil && child.EngineRef() != nil {
+			v.Table.MySQL.Engine = v.getTextOrIdentifier(child.EngineRef().TextOrIdentifier())
+			continue
+		}
+		if child.ROW_FORMAT_SYMBOL() != nil && child.GetFormat() != nil {
+			v.Table.MySQL.RowFormat = strings.ToUpper(child.GetFormat().GetText())
+			continue
+		}
+		if child.KEY_BLOCK_SIZE_SYMBOL() != nil && child.Ulong_number() != nil {
+			size, err := strconv.Atoi(child.Ulong_number().GetText())
+			if err != nil {
+				v.Err = fmt.Errorf("invalid key_block_size value: %s", child.Ulong_number().GetText())
+				return nil
+			}
+			v.Table.MySQL.KeyBlockSize = size
+			continue
+		}
+		if child.TABLESPACE_SYMBOL() != nil && child.Identifier() != nil {
+			v.Table.Tablespace, _ = normalizeIdentifier(types.MySQL, child.Identifier().GetText())
 		}
 	}
 	return nil
 }
 
-func (v *MySQLVisitor) parseColumnType(dataType string) (column *types.AntlrColumn, err error) {
-	originalType, length, scale, err := v.extractColumnTypeInfo(dataType)
+// setPartitioning sets the PARTITION BY clause with its subpartitioning and partition definitions.
+func (v *MySQLVisitor) setPartitioning(ctx parser.IPartitionClauseContext) {
+	partitioning := &types.Partitioning{}
+	switch def := ctx.PartitionTypeDef().(type) {
+	case *parser.PartitionDefKeyContext:
+		partitioning.Strategy = types.KeyPartition
+		if def.IdentifierList() != nil {
+			partitioning.Keys = v.getIdentifiers(def.IdentifierList())
+		}
+	case *parser.PartitionDefHashContext:
+		partitioning.Strategy = types.HashPartition
+		partitioning.Keys = []string{getOriginalText(def.BitExpr())}
+	case *parser.PartitionDefRangeListContext:
+		partitioning.Strategy = If(def.RANGE_SYMBOL() != nil, types.RangePartition, types.ListPartition)
+		if def.BitExpr() != nil {
+			partitioning.Keys = []string{getOriginalText(def.BitExpr())}
+		} else if def.IdentifierList() != nil {
+			// RANGE COLUMNS / LIST COLUMNS
+			partitioning.Keys = v.getIdentifiers(def.IdentifierList())
+		}
+	}
+	if ctx.Real_ulong_number() != nil {
+		partitioning.Count, _ = strconv.Atoi(ctx.Real_ulong_number().GetText())
+	}
+
+	if sub := ctx.SubPartitions(); sub != nil {
+		partitioning.Subpartitioning = &types.Partitioning{}
+		if sub.HASH_SYMBOL() != nil {
+			partitioning.Subpartitioning.Strategy = types.HashPartition
+			partitioning.Subpartitioning.Keys = []string{getOriginalText(sub.BitExpr())}
+		} else {
+			partitioning.Subpartitioning.Strategy = types.KeyPartition
+			partitioning.Subpartitioning.Keys = v.getIdentifiers(sub.IdentifierListWithParentheses().IdentifierList())
+		}
+		if sub.Real_ulong_number() != nil {
+			partitioning.Subpartitioning.Count, _ = strconv.Atoi(sub.Real_ulong_number().GetText())
+		}
+	}
+
+	if ctx.PartitionDefinitions() != nil {
+		for _, def := range ctx.PartitionDefinitions().AllPartitionDefinition() {
+			partitioning.Definitions = append(partitioning.Definitions, v.getPartitionDefinition(def))
+		}
+	}
+	v.Table.Partitioning = partitioning
+}
+
+// getPartitionDefinition returns a partition with its VALUES LESS THAN / VALUES IN bounds,
+// tablespace, comment and subpartitions.
+func (v *MySQLVisitor) getPartitionDefinition(ctx parser.IPartitionDefinitionContext) *types.PartitionDefinition {
+	partition := &types.PartitionDefinition{}
+	partition.Name, _ = normalizeIdentifier(types.MySQL, ctx.Identifier().GetText())
+	switch {
+	case ctx.MAXVALUE_SYMBOL() != nil:
+		partition.To = []string{"MAXVALUE"}
+	case ctx.PartitionValueItemListParen() != nil:
+		partition.To = v.getPartitionValues(ctx.PartitionValueItemListParen())
+	case ctx.PartitionValuesIn() != nil:
+		values := ctx.PartitionValuesIn().AllPartitionValueItemListParen()
+		if len(values) == 1 && ctx.PartitionValuesIn().OPEN_PAR_SYMBOL() == nil {
+			// VALUES IN (1, 2, 3)
+			partition.In = v.getPartitionValues(values[0])
+			break
+		}
+		// VALUES IN ((1, 'a'), (2, 'b')) of LIST COLUMNS
+		for _, value := range values {
+			partition.In = append(partition.In, getOriginalText(value))
+		}
+	}
+	v.setPartitionOptions(partition, ctx.AllPartitionOption())
+
+	for _, def := range ctx.AllSubpartitionDefinition() {
+		sub := &types.PartitionDefinition{Name: v.getTextOrIdentifier(def.TextOrIdentifier())}
+		v.setPartitionOptions(sub, def.AllPartitionOption())
+		partition.Subpartitions = append(partition.Subpartitions, sub)
+	}
+	return partition
+}
+
+func (v *MySQLVisitor) getPartitionValues(ctx parser.IPartitionValueItemListParenContext) []string {
+	var values []string
+	for _, item := range ctx.AllPartitionValueItem() {
+		values = append(values, getOriginalText(item))
+	}
+	return values
+}
+
+func (v *MySQLVisitor) setPartitionOptions(partition *types.PartitionDefinition, opts []parser.IPartitionOptionContext) {
+	for _, opt := range opts {
+		if opt.TABLESPACE_SYMBOL() != nil && opt.Identifier() != nil {
+			partition.Tablespace, _ = normalizeIdentifier(types.MySQL, opt.Identifier().GetText())
+		}
+		if opt.COMMENT_SYMBOL() != nil && opt.TextLiteral() != nil {
+			partition.Comment = v.getText(opt.TextLiteral())
+		}
+	}
+}
+
+func (v *MySQLVisitor) getIdentifiers(ctx parser.IIdentifierListContext) []string {
+	var names []string
+	for _, ident := range ctx.AllIdentifier() {
+		name, _ := normalizeIdentifier(types.MySQL, ident.GetText())
+		names = append(names, name)
+	}
+	return names
+}
+
+// getTextOrIdentifier returns the value of a name given as a string literal or an identifier.
+func (v *MySQLVisitor) getTextOrIdentifier(ctx parser.ITextOrIdentifierContext) string {
+	if ctx.TextStringLiteral() != nil {
+		return decodeString(types.MySQL, ctx.TextStringLiteral().GetText())
+	}
+	name, _ := normalizeIdentifier(types.MySQL, ctx.GetText())
+	return name
+}
+
+// mysqlDataTypes maps the type token of the dataType rule to the type name,
+// synonyms are resolved here, e.g. INTEGER and INT4 are lexed as INT.
+var mysqlDataTypes = map[int]string{
+	parser.MySQLParserINT_SYMBOL:                "int",
+	parser.MySQLParserTINYINT_SYMBOL:            "tinyint",
+	parser.MySQLParserSMALLINT_SYMBOL:           "smallint",
+	parser.MySQLParserMEDIUMINT_SYMBOL:          "mediumint",
+	parser.MySQLParserBIGINT_SYMBOL:             "bigint",
+	parser.MySQLParserREAL_SYMBOL:               "double", // unless the REAL_AS_FLOAT sql mode is set
+	parser.MySQLParserDOUBLE_SYMBOL:             "double",
+	parser.MySQLParserFLOAT_SYMBOL:              "float",
+	parser.MySQLParserDECIMAL_SYMBOL:            "decimal",
+	parser.MySQLParserNUMERIC_SYMBOL:            "decimal",
+	parser.MySQLParserFIXED_SYMBOL:              "decimal",
+	parser.MySQLParserBIT_SYMBOL:                "bit",
+	parser.MySQLParserBOOL_SYMBOL:               "boolean",
+	parser.MySQLParserBOOLEAN_SYMBOL:            "boolean",
+	parser.MySQLParserCHAR_SYMBOL:               "char",
+	parser.MySQLParserBINARY_SYMBOL:             "binary",
+	parser.MySQLParserVARCHAR_SYMBOL:            "varchar",
+	parser.MySQLParserNATIONAL_SYMBOL:           "nvarchar",
+	parser.MySQLParserNVARCHAR_SYMBOL:           "nvarchar",
+	parser.MySQLParserNCHAR_SYMBOL:              "nvarchar",
+	parser.MySQLParserVARBINARY_SYMBOL:          "varbinary",
+	parser.MySQLParserYEAR_SYMBOL:               "year",
+	parser.MySQLParserDATE_SYMBOL:               "date",
+	parser.MySQLParserTIME_SYMBOL:               "time",
+	parser.MySQLParserTIMESTAMP_SYMBOL:          "timestamp",
+	parser.MySQLParserDATETIME_SYMBOL:           "datetime",
+	parser.MySQLParserTINYBLOB_SYMBOL:           "tinyblob",
+	parser.MySQLParserBLOB_SYMBOL:               "blob",
+	parser.MySQLParserMEDIUMBLOB_SYMBOL:         "mediumblob",
+	parser.MySQLParserLONGBLOB_SYMBOL:           "longblob",
+	parser.MySQLParserLONG_SYMBOL:               "mediumtext",
+	parser.MySQLParserTINYTEXT_SYMBOL:           "tinytext",
+	parser.MySQLParserTEXT_SYMBOL:               "text",
+	parser.MySQLParserMEDIUMTEXT_SYMBOL:         "mediumtext",
+	parser.MySQLParserLONGTEXT_SYMBOL:           "longtext",
+	parser.MySQLParserENUM_SYMBOL:               "enum",
+	parser.MySQLParserSET_SYMBOL:                "set",
+	parser.MySQLParserSERIAL_SYMBOL:             "serial",
+	parser.MySQLParserJSON_SYMBOL:               "json",
+	parser.MySQLParserGEOMETRY_SYMBOL:           "geometry",
+	parser.MySQLParserGEOMETRYCOLLECTION_SYMBOL: "geometrycollection",
+	parser.MySQLParserPOINT_SYMBOL:              "point",
+	parser.MySQLParserMULTIPOINT_SYMBOL:         "multipoint",
+	parser.MySQLParserLINESTRING_SYMBOL:         "linestring",
+	parser.MySQLParserMULTILINESTRING_SYMBOL:    "multilinestring",
+	parser.MySQLParserPOLYGON_SYMBOL:            "polygon",
+	parser.MySQLParserMULTIPOLYGON_SYMBOL:       "multipolygon",
+}
+
+func (v *MySQLVisitor) parseColumnType(ctx parser.IDataTypeContext) (column *types.AntlrColumn, err error) {
+	originalType, length, scale, err := v.extractColumnTypeInfo(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -158,23 +512,58 @@ func (v *MySQLVisitor) parseColumnType(dataType string) (column *types.AntlrColu
 		return nil, err
 	}
 
+	if opts := ctx.FieldOptions(); opts != nil {
+		// ZEROFILL implies UNSIGNED
+		column.Zerofill = len(opts.AllZEROFILL_SYMBOL()) > 0
+		column.Unsigned = column.Zerofill || len(opts.AllUNSIGNED_SYMBOL()) > 0
+	}
+	if ctx.CharsetWithOptBinary() != nil {
+		column.Charset = v.getCharset(ctx.CharsetWithOptBinary())
+	}
+	if ctx.StringList() != nil {
+		for _, s := range ctx.StringList().AllTextString() {
+			column.Values = append(column.Values, decodeString(types.MySQL, s.GetText()))
+		}
+	}
+
 	v.setColumnAttributes(column, originalType, length, scale)
 	return column, nil
 }
 
-func (v *MySQLVisitor) extractColumnTypeInfo(dataType string) (originalType string, length int, scale int, err error) {
-	re := regexp.MustCompile(`(?i)(\w+)(?:\((\d+)(?:,(\d+))?\))?`)
-	matches := re.FindStringSubmatch(dataType)
-	if len(matches) == 0 {
-		return "", 0, 0, errors.New("unknown column define: " + dataType)
+// extractColumnTypeInfo returns the type name of the dataType rule with its length and scale,
+// multi-word types are resolved from the tokens around the type, e.g. CHAR VARYING -> varchar.
+func (v *MySQLVisitor) extractColumnTypeInfo(ctx parser.IDataTypeContext) (originalType string, length int, scale int, err error) {
+	if ctx.Nchar() != nil {
+		originalType = "nchar"
+	} else if ctx.GetType_() != nil {
+		originalType = mysqlDataTypes[ctx.GetType_().GetTokenType()]
+	}
+	if originalType == "" {
+		return "", 0, 0, errors.New("unknown column define: " + ctx.GetText())
 	}
 
-	originalType = strings.ToLower(matches[1])
-	if len(matches) > 2 && matches[2] != "" {
-		length, _ = strconv.Atoi(matches[2])
+	fieldLength, precision := ctx.FieldLength(), ctx.Precision()
+	if ctx.FloatOptions() != nil {
+		fieldLength, precision = ctx.FloatOptions().FieldLength(), ctx.FloatOptions().Precision()
+	}
+	if fieldLength != nil {
+		n, _ := strconv.ParseFloat(strings.Trim(fieldLength.GetText(), "()"), 64)
+		length = int(n)
 	}
-	if len(matches) > 3 && matches[3] != "" {
-		scale, _ = strconv.Atoi(matches[3])
+	if precision != nil && len(precision.AllINT_NUMBER()) == 2 {
+		length, _ = strconv.Atoi(precision.INT_NUMBER(0).GetText())
+		scale, _ = strconv.Atoi(precision.INT_NUMBER(1).GetText())
+	}
+
+	switch {
+	case originalType == "char" && ctx.VARYING_SYMBOL() != nil:
+		originalType = "varchar"
+	case originalType == "mediumtext" && ctx.VARBINARY_SYMBOL() != nil:
+		originalType = "mediumblob" // LONG VARBINARY
+	case originalType == "float" && fieldLength != nil:
+		// FLOAT(p) gives the precision in bits, a double is used above 24
+		originalType = If(length > 24, "double", "float")
+		length = 0
 	}
 	return originalType, length, scale, nil
 }
@@ -188,18 +577,60 @@ func (v *MySQLVisitor) mapColumnType(originalType string) (string, error) {
 
 func (v *MySQLVisitor) setColumnAttributes(column *types.AntlrColumn, originalType string, length int, scale int) {
 	switch originalType {
-	case "char", "varchar", "string", "text", "tinytext", "mediumtext", "longtext":
+	case "char", "varchar", "nchar", "nvarchar", "text", "tinytext", "mediumtext", "longtext", "json":
 		column.StringLength = If(length > 0 && length < 50, length, 50)
+		column.CharLength = If((originalType == "char" || originalType == "nchar") && length == 0, 1, length)
+		if bytes, ok := mysqlTextBytes[originalType]; ok && length == 0 {
+			// text types are limited in bytes, not characters
+			column.LengthUnit = types.Bytes
+			column.ByteLength = bytes
+		}
+		if originalType == "nchar" || originalType == "nvarchar" {
+			// the national charset
+			column.Charset = "utf8mb3"
+		}
+	case "enum", "set":
+		for _, value := range column.Values {
+			if originalType == "enum" {
+				column.CharLength = max(column.CharLength, len([]rune(value)))
+			} else {
+				column.CharLength += len([]rune(value)) + 1
+			}
+		}
+		if originalType == "set" && column.CharLength > 0 {
+			column.CharLength-- // no comma after the last value
+		}
+		column.StringLength = If(column.CharLength < 50, column.CharLength, 50)
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		column.LengthUnit = types.Bytes
+		column.ByteLength = If(originalType == "binary" && length == 0, 1, length)
+		if bytes, ok := mysqlTextBytes[originalType]; ok && length == 0 {
+			column.ByteLength = bytes
+		}
+		column.StringLength = If(column.ByteLength > 0 && column.ByteLength < 50, column.ByteLength, 50)
 	case "tinyint":
-		column.MaxInteger = math.MaxInt8
+		column.MaxInteger = If[int64](column.Unsigned, math.MaxUint8, math.MaxInt8)
 	case "smallint":
-		column.MaxInteger = math.MaxInt16
+		column.MaxInteger = If[int64](column.Unsigned, math.MaxUint16, math.MaxInt16)
 	case "mediumint":
-		column.MaxInteger = 1<<23 - 1 // 8388607
+		column.MaxInteger = If[int64](column.Unsigned, 1<<24-1, 1<<23-1) // 16777215, 8388607
 	case "int", "integer":
-		column.MaxInteger = math.MaxInt32
+		column.MaxInteger = If[int64](column.Unsigned, math.MaxUint32, math.MaxInt32)
 	case "bigint":
+		column.MaxInteger = math.MaxInt64 // unsigned values above it do not fit in int64
+	case "serial":
+		// BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE
 		column.MaxInteger = math.MaxInt64
+		column.Unsigned = true
+		column.NotNull = true
+		column.AutoIncrement = true
+		column.Identity = newIdentity(true)
+	case "bit":
+		bits := If(length > 0, length, 1)
+		column.MaxInteger = If[int64](bits < 63, 1<<bits-1, math.MaxInt64)
+	case "year":
+		column.MinInteger = 1901
+		column.MaxInteger = 2155
 	case "float", "real":
 		column.MaxFloat = getMaxFloat32(length)
 		column.Scale = If(scale > 0, scale, 2)
@@ -208,3 +639,41 @@ func (v *MySQLVisitor) setColumnAttributes(column *types.AntlrColumn, originalTy
 		column.Scale = If(scale > 0, scale, 2)
 	}
 }
+
+// mysqlTextBytes is the max bytes of the text and blob types, the 4GB - 1 of the long types is
+// capped at math.MaxInt32 to fit an int on 32-bit platforms.
+var mysqlTextBytes = map[string]int{
+	"tinytext":   1<<8 - 1,
+	"text":       1<<16 - 1,
+	"mediumtext": 1<<24 - 1,
+	"longtext":   math.MaxInt32,
+	"json":       math.MaxInt32,
+	"tinyblob":   1<<8 - 1,
+	"blob":       1<<16 - 1,
+	"mediumblob": 1<<24 - 1,
+	"longblob":   math.MaxInt32,
+}
+
+// mysqlName trims the quotes around a charset or collation name and lower cases it.
+func mysqlName(name string) string {
+	return strings.ToLower(strings.Trim(name, "`'\""))
+}
+
+// mysqlCharset normalizes a charset name, DEFAULT means the table default charset.
+func mysqlCharset(name string) string {
+	switch name = mysqlName(name); name {
+	case "default":
+		return ""
+	case "utf8":
+		return "utf8mb3"
+	}
+	return name
+}
+
+// mysqlCollationCharset returns the charset of a collation, e.g. utf8mb4_general_ci -> utf8mb4.
+func mysqlCollationCharset(collation string) string {
+	if i := strings.Index(collation, "_"); i > 0 {
+		return mysqlCharset(collation[:i])
+	}
+	return mysqlCharset(collation)
+}
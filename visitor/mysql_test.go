@@ -0,0 +1,316 @@
+package visitor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/aierdong/createtable-sql-parser/types"
+)
+
+// lengthCase is the expected length semantics of a string column.
+type lengthCase struct {
+	column     string
+	unit       types.LengthUnit
+	charLength int
+	byteLength int
+	charset    string
+}
+
+func checkLengths(t *testing.T, table *types.AntlrTable, tests []lengthCase) {
+	t.Helper()
+	if len(table.Columns) != len(tests) {
+		t.Fatalf("columns = %d, want %d", len(table.Columns), len(tests))
+	}
+	for i, tt := range tests {
+		c := table.Columns[i]
+		if c.Name != tt.column || c.LengthUnit != tt.unit || c.CharLength != tt.charLength || c.ByteLength != tt.byteLength || c.Charset != tt.charset {
+			t.Errorf("column %s: LengthUnit = %q, CharLength = %d, ByteLength = %d, Charset = %q, want %q, %d, %d, %q",
+				c.Name, c.LengthUnit, c.CharLength, c.ByteLength, c.Charset, tt.unit, tt.charLength, tt.byteLength, tt.charset)
+		}
+	}
+}
+
+func TestParseMySqlStringLength(t *testing.T) {
+	table, err := ParseMySql("CREATE TABLE t (" +
+		"a varchar(10), " +
+		"b char(10) CHARACTER SET latin1, " +
+		"c text, " +
+		"d varchar(20) COLLATE utf8mb3_bin, " +
+		"e longtext, " +
+		"f nvarchar(10)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkLengths(t, table, []lengthCase{
+		{"a", types.Chars, 10, 40, "utf8mb4"},
+		{"b", types.Chars, 10, 10, "latin1"},
+		{"c", types.Bytes, 65535 / 4, 65535, "utf8mb4"},
+		{"d", types.Chars, 20, 60, "utf8mb3"},
+		{"e", types.Bytes, math.MaxInt32 / 4, math.MaxInt32, "utf8mb4"},
+		{"f", types.Chars, 10, 30, "utf8mb3"},
+	})
+}
+
+func TestParseMySqlComment(t *testing.T) {
+	table, err := ParseMySql("CREATE TABLE `t` (\n" +
+		"  `a` int COMMENT 'it''s \\'quoted\\'',\n" +
+		"  `b` int COMMENT _utf8mb4'line\\nbreak' ' and more',\n" +
+		"  `c` int COMMENT \"say \"\"hi\"\"\",\n" +
+		"  `d` int COMMENT N'Name'\n" +
+		") ENGINE=InnoDB COMMENT='C:\\\\data 100\\%'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{`it's 'quoted'`, "line\nbreak and more", `say "hi"`, "Name"}
+	for i, c := range table.Columns {
+		if c.Comment != want[i] {
+			t.Errorf("%s: comment = %q, want %q", c.Name, c.Comment, want[i])
+		}
+	}
+	if table.Comment != `C:\data 100\%` {
+		t.Errorf("table comment = %q, want %q", table.Comment, `C:\data 100\%`)
+	}
+}
+
+func TestParseMySqlTableOptions(t *testing.T) {
+	table, err := ParseMySql("CREATE TABLE `t` (\n" +
+		"  `id` bigint NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(10),\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB AUTO_INCREMENT=1001 DEFAULT CHARSET=latin1 COLLATE=latin1_bin " +
+		"ROW_FORMAT=compressed KEY_BLOCK_SIZE=8 TABLESPACE `ts1` COMMENT='orders'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &types.MySQLTable{Engine: "InnoDB", RowFormat: "COMPRESSED", AutoIncrement: 1001, KeyBlockSize: 8}
+	if !reflect.DeepEqual(table.MySQL, want) {
+		t.Errorf("MySQL = %+v, want %+v", table.MySQL, want)
+	}
+	if table.Charset != "latin1" || table.Collation != "latin1_bin" || table.Tablespace != "ts1" || table.Comment != "orders" {
+		t.Errorf("Charset = %q, Collation = %q, Tablespace = %q, Comment = %q, want latin1, latin1_bin, ts1, orders",
+			table.Charset, table.Collation, table.Tablespace, table.Comment)
+	}
+	if id := table.Columns[0]; id.Identity == nil || id.Identity.Seed != 1001 {
+		t.Errorf("id: Identity = %+v, want seed 1001", id.Identity)
+	}
+	if name := table.Columns[1]; name.Charset != "latin1" || name.Collation != "latin1_bin" || name.ByteLength != 10 {
+		t.Errorf("name: Charset = %q, Collation = %q, ByteLength = %d, want the table default latin1", name.Charset, name.Collation, name.ByteLength)
+	}
+}
+
+func TestParseMySqlPartitioning(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want *types.Partitioning
+	}{
+		{
+			name: "range",
+			sql: "CREATE TABLE t (id int, d date) PARTITION BY RANGE (YEAR(d)) (" +
+				"PARTITION p0 VALUES LESS THAN (1990) TABLESPACE ts0 COMMENT 'old', " +
+				"PARTITION p1 VALUES LESS THAN MAXVALUE)",
+			want: &types.Partitioning{
+				Strategy: types.RangePartition,
+				Keys:     []string{"YEAR(d)"},
+				Definitions: []*types.PartitionDefinition{
+					{Name: "p0", To: []string{"1990"}, Tablespace: "ts0", Comment: "old"},
+					{Name: "p1", To: []string{"MAXVALUE"}},
+				},
+			},
+		},
+		{
+			name: "range columns",
+			sql: "CREATE TABLE t (a int, b int) PARTITION BY RANGE COLUMNS (a, `b`) (" +
+				"PARTITION p0 VALUES LESS THAN (10, 20))",
+			want: &types.Partitioning{
+				Strategy:    types.RangePartition,
+				Keys:        []string{"a", "b"},
+				Definitions: []*types.PartitionDefinition{{Name: "p0", To: []string{"10", "20"}}},
+			},
+		},
+		{
+			name: "list",
+			sql:  "CREATE TABLE t (id int) PARTITION BY LIST (id) (PARTITION p0 VALUES IN (1, 2), PARTITION p1 VALUES IN (3))",
+			want: &types.Partitioning{
+				Strategy: types.ListPartition,
+				Keys:     []string{"id"},
+				Definitions: []*types.PartitionDefinition{
+					{Name: "p0", In: []string{"1", "2"}},
+					{Name: "p1", In: []string{"3"}},
+				},
+			},
+		},
+		{
+			name: "list columns",
+			sql:  "CREATE TABLE t (a int, b char(1)) PARTITION BY LIST COLUMNS (a, b) (PARTITION p0 VALUES IN ((1,'x'), (2,'y')))",
+			want: &types.Partitioning{
+				Strategy:    types.ListPartition,
+				Keys:        []string{"a", "b"},
+				Definitions: []*types.PartitionDefinition{{Name: "p0", In: []string{"(1,'x')", "(2,'y')"}}},
+			},
+		},
+		{
+			name: "hash",
+			sql:  "CREATE TABLE t (id int) PARTITION BY HASH (id) PARTITIONS 4",
+			want: &types.Partitioning{Strategy: types.HashPartition, Keys: []string{"id"}, Count: 4},
+		},
+		{
+			name: "key",
+			sql:  "CREATE TABLE t (id int) PARTITION BY KEY (id) PARTITIONS 2",
+			want: &types.Partitioning{Strategy: types.KeyPartition, Keys: []string{"id"}, Count: 2},
+		},
+		{
+			name: "subpartitions",
+			sql: "CREATE TABLE t (id int, d date) PARTITION BY RANGE (YEAR(d)) SUBPARTITION BY HASH (TO_DAYS(d)) SUBPARTITIONS 2 (" +
+				"PARTITION p0 VALUES LESS THAN (2000) (SUBPARTITION s0, SUBPARTITION s1 TABLESPACE ts1))",
+			want: &types.Partitioning{
+				Strategy:        types.RangePartition,
+				Keys:            []string{"YEAR(d)"},
+				Subpartitioning: &types.Partitioning{Strategy: types.HashPartition, Keys: []string{"TO_DAYS(d)"}, Count: 2},
+				Definitions: []*types.PartitionDefinition{{
+					Name: "p0",
+					To:   []string{"2000"},
+					Subpartitions: []*types.PartitionDefinition{
+						{Name: "s0"},
+						{Name: "s1", Tablespace: "ts1"},
+					},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := ParseMySql(tt.sql)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(table.Partitioning, tt.want) {
+				t.Errorf("Partitioning = %+v, want %+v", table.Partitioning, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMySqlTypes(t *testing.T) {
+	table, err := ParseMySql(`CREATE TABLE t (
+  a BIT(4),
+  b BIT,
+  c YEAR,
+  d BOOL,
+  e BOOLEAN,
+  f SERIAL,
+  g INT(10) UNSIGNED ZEROFILL,
+  h TINYINT ZEROFILL,
+  i DOUBLE PRECISION,
+  j FLOAT(30),
+  k DEC(10,3)
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		dataType   types.DbType
+		maxInteger int64
+		minInteger int64
+		unsigned   bool
+		zerofill   bool
+	}{
+		{"a", types.Integer, 15, 0, false, false},
+		{"b", types.Integer, 1, 0, false, false},
+		{"c", types.Integer, 2155, 1901, false, false},
+		{"d", types.Boolean, 0, 0, false, false},
+		{"e", types.Boolean, 0, 0, false, false},
+		{"f", types.Integer, math.MaxInt64, 0, true, false},
+		{"g", types.Integer, math.MaxUint32, 0, true, true},
+		{"h", types.Integer, math.MaxUint8, 0, true, true},
+		{"i", types.Numeric, 0, 0, false, false},
+		{"j", types.Numeric, 0, 0, false, false},
+		{"k", types.Numeric, 0, 0, false, false},
+	}
+	if len(table.Columns) != len(tests) {
+		t.Fatalf("columns = %d, want %d", len(table.Columns), len(tests))
+	}
+	for i, tt := range tests {
+		c := table.Columns[i]
+		if c.Name != tt.name || c.DataType != tt.dataType || c.MaxInteger != tt.maxInteger || c.MinInteger != tt.minInteger ||
+			c.Unsigned != tt.unsigned || c.Zerofill != tt.zerofill {
+			t.Errorf("column %s: DataType = %s, MaxInteger = %d, MinInteger = %d, Unsigned = %v, Zerofill = %v, want %s, %d, %d, %v, %v",
+				c.Name, c.DataType, c.MaxInteger, c.MinInteger, c.Unsigned, c.Zerofill, tt.dataType, tt.maxInteger, tt.minInteger, tt.unsigned, tt.zerofill)
+		}
+	}
+
+	// SERIAL is BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE
+	if f := table.Columns[5]; !f.NotNull || !f.AutoIncrement || f.Identity == nil {
+		t.Errorf("f: NotNull = %v, AutoIncrement = %v, want a serial column", f.NotNull, f.AutoIncrement)
+	}
+	want := []*types.Constraint{{Type: types.Unique, Columns: []string{"f"}}}
+	if !reflect.DeepEqual(table.Constraints, want) {
+		t.Errorf("constraints = %+v, want %+v", table.Constraints, want)
+	}
+	if j := table.Columns[9]; j.MaxFloat != getMaxFloat64(0) {
+		t.Errorf("j: MaxFloat = %v, want FLOAT(30) as a double", j.MaxFloat)
+	}
+	if k := table.Columns[10]; k.MaxFloat != getMaxFloat64(10) || k.Scale != 3 {
+		t.Errorf("k: MaxFloat = %v, Scale = %d, want DECIMAL(10,3)", k.MaxFloat, k.Scale)
+	}
+}
+
+func TestParseMySqlStringTypes(t *testing.T) {
+	table, err := ParseMySql(`CREATE TABLE t (
+  a NATIONAL CHAR(10),
+  b NVARCHAR(10),
+  c NATIONAL VARCHAR(10),
+  d CHARACTER VARYING(20),
+  e ENUM('a', 'bcd', 'éé'),
+  f SET('a', 'bc')
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkLengths(t, table, []lengthCase{
+		{"a", types.Chars, 10, 30, "utf8mb3"},
+		{"b", types.Chars, 10, 30, "utf8mb3"},
+		{"c", types.Chars, 10, 30, "utf8mb3"},
+		{"d", types.Chars, 20, 80, "utf8mb4"},
+		{"e", types.Chars, 3, 12, "utf8mb4"},
+		{"f", types.Chars, 4, 16, "utf8mb4"},
+	})
+	if a := table.Columns[0]; a.DataType != types.Char {
+		t.Errorf("a: DataType = %s, want char", a.DataType)
+	}
+	if e := table.Columns[4]; !reflect.DeepEqual(e.Values, []string{"a", "bcd", "éé"}) {
+		t.Errorf("e: Values = %q, want a, bcd, éé", e.Values)
+	}
+}
+
+func TestParseMySqlColumnAttributes(t *testing.T) {
+	table, err := ParseMySql(`CREATE TABLE t (
+  id int SERIAL DEFAULT VALUE,
+  ts timestamp(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) INVISIBLE,
+  x int COLUMN_FORMAT fixed STORAGE disk,
+  g geometry NOT NULL SRID 4326
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id := table.Columns[0]; !id.NotNull || !id.AutoIncrement || len(table.Constraints) != 1 || table.Constraints[0].Type != types.Unique {
+		t.Errorf("id: NotNull = %v, AutoIncrement = %v, constraints = %+v, want SERIAL DEFAULT VALUE", id.NotNull, id.AutoIncrement, table.Constraints)
+	}
+	ts := table.Columns[1]
+	if ts.Default != "CURRENT_TIMESTAMP(3)" || ts.OnUpdate != "CURRENT_TIMESTAMP(3)" || !ts.Invisible || !ts.NotNull {
+		t.Errorf("ts: Default = %q, OnUpdate = %q, Invisible = %v, NotNull = %v", ts.Default, ts.OnUpdate, ts.Invisible, ts.NotNull)
+	}
+	if x := table.Columns[2]; !reflect.DeepEqual(x.MySQL, &types.MySQLColumn{ColumnFormat: "FIXED", Storage: "DISK"}) {
+		t.Errorf("x: MySQL = %+v, want COLUMN_FORMAT FIXED STORAGE DISK", x.MySQL)
+	}
+	if g := table.Columns[3]; g.DataType != types.Geometry || g.MySQL == nil || g.MySQL.SRID != 4326 {
+		t.Errorf("g: DataType = %s, MySQL = %+v, want geometry SRID 4326", g.DataType, g.MySQL)
+	}
+}
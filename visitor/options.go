@@ -0,0 +1,63 @@
+package visitor
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Option configures the Parse functions.
+type Option func(*options)
+
+type options struct {
+	inlineComments bool
+	mysqlVersion   int // e.g. 80040 for 8.0.40
+}
+
+// WithInlineComments uses the -- and /* */ comments next to a column or table as its comment
+// when it has no COMMENT clause or statement, e.g. for sqlite which has no COMMENT syntax.
+func WithInlineComments() Option {
+	return func(o *options) {
+		o.inlineComments = true
+	}
+}
+
+// WithMySQLVersion sets the server version, e.g. "5.7.44", deciding which /*!NNNNN ... */
+// conditional comments of a mysql script are part of the statements, it defaults to 8.0.40.
+// An invalid version is ignored.
+func WithMySQLVersion(version string) Option {
+	return func(o *options) {
+		if v, ok := parseMySQLVersion(version); ok {
+			o.mysqlVersion = v
+		}
+	}
+}
+
+// parseMySQLVersion converts a version as major.minor.patch to the number used by conditional
+// comments, a version already given as a number is returned as is.
+func parseMySQLVersion(version string) (int, bool) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	if len(parts) == 1 {
+		n, err := strconv.Atoi(parts[0])
+		return n, err == nil
+	}
+	n := 0
+	for i := 0; i < 3; i++ {
+		part := 0
+		if i < len(parts) {
+			var err error
+			if part, err = strconv.Atoi(parts[i]); err != nil || part > 99 {
+				return 0, false
+			}
+		}
+		n = n*100 + part
+	}
+	return n, true
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{mysqlVersion: 80040}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -17,9 +17,147 @@ type PgVisitor struct {
 	Table  *types.AntlrTable
 	Column *types.AntlrColumn
 	Err    error
+
+	comments  *antlr.CommonTokenStream // set when inline comments are used
+	userTypes map[string]*pgType       // types declared in the script, keyed by qualified and unqualified name
+	script    *pgScript                // the script of the table, nil when parsing a single statement
+}
+
+// pgScript is a script of several statements, the tables of the script are looked up for
+// the columns a table inherits from its parents.
+type pgScript struct {
+	sqls      []string
+	opts      *options
+	dialect   types.Dialect // PostgreSQL, or KingbaseES
+	userTypes map[string]*pgType
+	tables    map[int]*types.AntlrTable // parsed CREATE TABLE statements, keyed by statement index
+	resolving map[string]bool           // tables being looked up, guards against cycles
+}
+
+// findTable returns the table of the script with the possibly schema qualified name, or nil.
+func (s *pgScript) findTable(name string) *types.AntlrTable {
+	if s == nil || s.resolving[name] {
+		return nil
+	}
+	s.resolving[name] = true
+	defer delete(s.resolving, name)
+
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, name)
+	for i, sql := range s.sqls {
+		if !isPgCreateTable(sql) {
+			continue
+		}
+		table, ok := s.tables[i]
+		if !ok {
+			table, _ = s.parseTable(sql)
+			s.tables[i] = table
+		}
+		if table != nil && pgIsTable(table, parts) {
+			return table
+		}
+	}
+	return nil
+}
+
+// parseTable parses the CREATE TABLE statement and folds the other statements of the script
+// about the table into it.
+func (s *pgScript) parseTable(sql string) (*types.AntlrTable, error) {
+	table, err := parsePgTable(sql, s)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyStatements(table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// applyStatements applies the ALTER TABLE and COMMENT ON statements about the table, like the
+// constraints, defaults and identities pg_dump adds after creating the tables, then the sequence
+// ownership and options. Statements about other objects are skipped.
+func (s *pgScript) applyStatements(table *types.AntlrTable) error {
+	for _, sql := range s.sqls {
+		var err error
+		switch head := statementHead(sql); {
+		case strings.HasPrefix(head, "ALTER TABLE"):
+			err = parsePgAlterTable(sql, table, s)
+		case strings.HasPrefix(head, "COMMENT ON"):
+			err = parsePgComment(sql, table)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// the defaults above link the serial columns to their sequences
+	for _, sql := range s.sqls {
+		if strings.HasPrefix(statementHead(sql), "ALTER SEQUENCE") {
+			seq, owner, err := parsePgSequenceOwner(sql)
+			if err != nil {
+				return err
+			}
+			setSequenceOwner(table, seq, owner)
+		}
+	}
+	for _, sql := range s.sqls {
+		if strings.HasPrefix(statementHead(sql), "CREATE SEQUENCE") {
+			seq, err := parsePgSequence(sql)
+			if err != nil {
+				return err
+			}
+			applySequence(table, seq)
+		}
+	}
+	return nil
+}
+
+// isPgCreateTable reports whether the statement is a CREATE TABLE statement, e.g. the CREATE
+// UNLOGGED TABLE of pg_dump, but not CREATE TABLESPACE.
+func isPgCreateTable(sql string) bool {
+	return pgCreateTableRegexp.MatchString(statementHead(sql))
+}
+
+var pgCreateTableRegexp = regexp.MustCompile(`^CREATE ((GLOBAL |LOCAL )?(TEMP|TEMPORARY) |UNLOGGED )?TABLE\b`)
+
+// pgIsTable reports whether the possibly schema qualified name parts name the table.
+func pgIsTable(table *types.AntlrTable, parts []string) bool {
+	if len(parts) == 0 || !identifierEqual(types.PostgreSQL, table.Name, parts[len(parts)-1]) {
+		return false
+	}
+	return len(parts) == 1 || table.Schema == "" || table.Schema == parts[len(parts)-2]
+}
+
+// setSequenceOwner sets the sequence of the serial column owning it, see ALTER SEQUENCE OWNED BY.
+func setSequenceOwner(table *types.AntlrTable, seq, owner string) {
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, owner)
+	if len(parts) < 2 || !pgIsTable(table, parts[:len(parts)-1]) {
+		return
+	}
+	if i := pgColumnIndex(table.Columns, parts[len(parts)-1]); i >= 0 && table.Columns[i].Identity != nil {
+		table.Columns[i].Identity.Sequence = seq
+	}
+}
+
+// pgType is a type declared in the script by CREATE DOMAIN or CREATE TYPE.
+type pgType struct {
+	column *types.AntlrColumn // the resolved type, with the domain NOT NULL and DEFAULT
+	checks []*pgCheck         // the domain CHECK constraints, on VALUE
+	err    error              // why the type could not be resolved, reported when a column uses it
+}
+
+// pgCheck is a domain CHECK constraint, its VALUE references become the column using the domain.
+type pgCheck struct {
+	name   string
+	text   string   // the expression as written
+	values [][2]int // rune offsets in text of the VALUE references
+}
+
+func ParsePgSql(sql string, opts ...Option) (*types.AntlrTable, error) {
+	return parsePgScript(sql, types.PostgreSQL, opts)
 }
 
-func ParsePgSql(sql string) (table *types.AntlrTable, err error) {
+// parsePgScript parses the script of postgres or of a dialect parsed as postgres, e.g. KingbaseES.
+func parsePgScript(sql string, dialect types.Dialect, opts []Option) (table *types.AntlrTable, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			table = nil
@@ -27,48 +165,40 @@ func ParsePgSql(sql string) (table *types.AntlrTable, err error) {
 		}
 	}()
 
-	sqls := strings.Split(sql, ";")
+	sqls := splitPgStatements(sql)
 
+	script := &pgScript{
+		sqls:      sqls,
+		opts:      newOptions(opts),
+		dialect:   dialect,
+		userTypes: make(map[string]*pgType),
+		tables:    make(map[int]*types.AntlrTable),
+		resolving: make(map[string]bool),
+	}
+
+	// domains and types are declared before the tables using them
 	for _, s := range sqls {
-		if len(s) > 12 && strings.ToUpper(s[:12]) == "CREATE TABLE" {
-			table, err = parsePgTable(s)
-			if err != nil {
+		head := statementHead(s)
+		if strings.HasPrefix(head, "CREATE DOMAIN") || strings.HasPrefix(head, "CREATE TYPE") {
+			if err := parsePgType(s, script); err != nil {
 				return nil, err
 			}
-			break
 		}
 	}
 
-	if table == nil {
-		return nil, errors.New("not dound create table statment")
-	}
-
 	for _, s := range sqls {
-		s = strings.TrimSpace(s)
-		if len(s) > 17 && strings.ToUpper(s[:17]) == "COMMENT ON COLUMN" {
-			col, err := parsePgColumnComment(s)
+		if isPgCreateTable(s) {
+			table, err = script.parseTable(s)
 			if err != nil {
 				return nil, err
 			}
-			if col == nil { // no comment
-				continue
-			}
-			for _, c := range table.Columns {
-				if c.Name == col.Name {
-					c.Comment = col.Comment
-				}
-			}
-			continue
-		}
-		if len(s) > 16 && strings.ToUpper(s[:16]) == "COMMENT ON TABLE" {
-			t, err := parsePgTableComment(s)
-			if err != nil {
-				return nil, err
-			}
-			table.Comment = t
+			break
 		}
 	}
 
+	if table == nil {
+		return nil, errors.New("not dound create table statment")
+	}
 	return table, nil
 }
 
@@ -81,35 +211,194 @@ func (v *PgVisitor) VisitCreatestmt(ctx *parser.CreatestmtContext) interface{} {
 		return err
 	}
 
-	tbl := ctx.Opttableelementlist()
-	if tbl == nil || len(tbl.GetChildren()) == 0 {
-		v.Err = errors.New("table element list is nil")
-		return nil
-	}
+	v.Table.Comment = inlineTableComment(v.comments, ctx, ctx.OPEN_PAREN())
+
+	if ctx.PARTITION() != nil {
+		v.setPartitionOf(ctx)
+	} else {
+		tbl := ctx.Opttableelementlist()
+		if (tbl == nil || len(tbl.GetChildren()) == 0) && ctx.Optinherit() == nil {
+			v.Err = errors.New("table element list is nil")
+			return nil
+		}
 
-	for _, child := range tbl.GetChild(0).GetChildren() {
-		if ele, ok := child.(*parser.TableelementContext); ok {
-			if col, ok := ele.GetChild(0).(*parser.ColumnDefContext); ok {
-				if err := col.Accept(v); err != nil {
-					return err
+		if tbl != nil && tbl.Tableelementlist() != nil {
+			for _, child := range tbl.Tableelementlist().AllTableelement() {
+				if col, ok := child.GetChild(0).(*parser.ColumnDefContext); ok {
+					if err := col.Accept(v); err != nil {
+						return err
+					}
+				}
+				if child.Tableconstraint() != nil {
+					v.addTableConstraint(child.Tableconstraint())
 				}
 			}
 		}
+		if ctx.Optinherit() != nil {
+			v.inheritColumns(ctx.Optinherit().Qualified_name_list())
+		}
 	}
 
+	if ctx.Optpartitionspec() != nil {
+		v.setPartitioning(ctx.Optpartitionspec().Partitionspec())
+	}
 	return nil
 }
 
+// inheritColumns puts the columns of the INHERITS parents found in the script before the
+// table own columns, a column declared again in the table is merged at the parent position.
+func (v *PgVisitor) inheritColumns(ctx parser.IQualified_name_listContext) {
+	var columns []*types.AntlrColumn
+	for _, q := range ctx.AllQualified_name() {
+		parts, _ := normalizeQualifiedName(types.PostgreSQL, q.GetText())
+		name := strings.Join(parts, ".")
+		v.postgresTable().Inherits = append(v.postgresTable().Inherits, name)
+
+		if parent := v.script.findTable(name); parent != nil {
+			for _, c := range parent.Columns {
+				if pgColumnIndex(columns, c.Name) < 0 {
+					columns = append(columns, clonePgColumn(c))
+				}
+			}
+		}
+	}
+	for _, c := range v.Table.Columns {
+		if i := pgColumnIndex(columns, c.Name); i >= 0 {
+			columns[i] = c
+		} else {
+			columns = append(columns, c)
+		}
+	}
+	v.Table.Columns = columns
+}
+
+// clonePgColumn returns a deep copy of a column of another table or of a declared type, so
+// that setting the copy does not change the original.
+func clonePgColumn(c *types.AntlrColumn) *types.AntlrColumn {
+	column := *c
+	if c.Identity != nil {
+		identity := *c.Identity
+		column.Identity = &identity
+	}
+	if c.Generated != nil {
+		generated := *c.Generated
+		column.Generated = &generated
+	}
+	column.Values = append([]string(nil), c.Values...)
+	column.Fields = nil
+	for _, f := range c.Fields {
+		column.Fields = append(column.Fields, clonePgColumn(f))
+	}
+	return &column
+}
+
+// setPartitionOf sets the parent and bound of a PARTITION OF table, its columns are the parent
+// columns when the parent is found in the script, with the column options of the partition.
+func (v *PgVisitor) setPartitionOf(ctx *parser.CreatestmtContext) {
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, ctx.Qualified_name(1).GetText())
+	name := strings.Join(parts, ".")
+	v.postgresTable().PartitionOf = name
+	if ctx.Partitionboundspec() != nil {
+		v.postgresTable().PartitionBound = v.getPartitionBound(ctx.Partitionboundspec())
+	}
+
+	if parent := v.script.findTable(name); parent != nil {
+		for _, c := range parent.Columns {
+			v.Table.Columns = append(v.Table.Columns, clonePgColumn(c))
+		}
+	}
+	if ctx.Opttypedtableelementlist() == nil {
+		return
+	}
+	for _, e := range ctx.Opttypedtableelementlist().Typedtableelementlist().AllTypedtableelement() {
+		if e.Tableconstraint() != nil {
+			v.addTableConstraint(e.Tableconstraint())
+			continue
+		}
+		if e.ColumnOptions() == nil {
+			continue
+		}
+		name, _ := normalizeIdentifier(types.PostgreSQL, e.ColumnOptions().Colid().GetText())
+		if i := pgColumnIndex(v.Table.Columns, name); i >= 0 {
+			v.setColumnConstraints(v.Table.Columns[i], e.ColumnOptions().Colquallist())
+		}
+	}
+}
+
+// getPartitionBound returns the FOR VALUES bound of a partition, bounds are expressions as written.
+func (v *PgVisitor) getPartitionBound(ctx parser.IPartitionboundspecContext) *types.PartitionDefinition {
+	bound := &types.PartitionDefinition{Name: v.Table.Name}
+	switch {
+	case ctx.DEFAULT() != nil:
+		bound.Default = true
+	case ctx.Hash_partbound() != nil:
+		for _, e := range ctx.Hash_partbound().AllHash_partbound_elem() {
+			switch strings.ToLower(e.Nonreservedword().GetText()) {
+			case "modulus":
+				bound.Modulus = pgIconst(e.Iconst())
+			case "remainder":
+				bound.Remainder = pgIconst(e.Iconst())
+			}
+		}
+	case ctx.IN_P() != nil:
+		bound.In = pgExpressions(ctx.Expr_list(0))
+	case ctx.FROM() != nil:
+		bound.From = pgExpressions(ctx.Expr_list(0))
+		bound.To = pgExpressions(ctx.Expr_list(1))
+	}
+	return bound
+}
+
+// setPartitioning sets the PARTITION BY strategy and keys, a key is a column or an expression.
+func (v *PgVisitor) setPartitioning(ctx parser.IPartitionspecContext) {
+	partitioning := &types.Partitioning{Strategy: strings.ToLower(ctx.Colid().GetText())}
+	for _, e := range ctx.Part_params().AllPart_elem() {
+		switch {
+		case e.Colid() != nil:
+			name, _ := normalizeIdentifier(types.PostgreSQL, e.Colid().GetText())
+			partitioning.Keys = append(partitioning.Keys, name)
+		case e.A_expr() != nil:
+			partitioning.Keys = append(partitioning.Keys, getOriginalText(e.A_expr()))
+		case e.Func_expr_windowless() != nil:
+			partitioning.Keys = append(partitioning.Keys, getOriginalText(e.Func_expr_windowless()))
+		}
+	}
+	v.Table.Partitioning = partitioning
+}
+
+// postgresTable returns the postgres specific options of the table, creating them if needed.
+func (v *PgVisitor) postgresTable() *types.PostgresTable {
+	if v.Table.Postgres == nil {
+		v.Table.Postgres = &types.PostgresTable{}
+	}
+	return v.Table.Postgres
+}
+
+func pgExpressions(ctx parser.IExpr_listContext) []string {
+	var values []string
+	for _, e := range ctx.AllA_expr() {
+		values = append(values, getOriginalText(e))
+	}
+	return values
+}
+
+func pgColumnIndex(columns []*types.AntlrColumn, name string) int {
+	for i, c := range columns {
+		if identifierEqual(types.PostgreSQL, c.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
 func (v *PgVisitor) VisitQualified_name(ctx *parser.Qualified_nameContext) interface{} {
-	arr := strings.Split(ctx.GetText(), ".")
-	if len(arr) == 2 {
-		v.Table.Database = strings.Trim(arr[0], "`")
-		v.Table.Name = strings.Trim(arr[1], "`")
-	} else if len(arr) == 1 {
-		v.Table.Name = strings.Trim(arr[0], "`")
-	} else {
+	parts, quoted := normalizeQualifiedName(types.PostgreSQL, ctx.GetText())
+	if len(parts) > 3 {
 		v.Err = errors.New("table name error")
+		return nil
 	}
+	setQualifiedName(v.Table, parts)
+	v.Table.Quoted = quoted
 	return nil
 }
 
@@ -117,32 +406,289 @@ func (v *PgVisitor) VisitColumnDef(ctx *parser.ColumnDefContext) interface{} {
 	col := &types.AntlrColumn{}
 	for _, child := range ctx.GetChildren() {
 		if ele, ok := child.(*parser.ColidContext); ok {
-			col.Name = strings.Trim(ele.GetText(), "\"")
+			col.Name, col.Quoted = normalizeIdentifier(types.PostgreSQL, ele.GetText())
 			continue
 		}
 		if ele, ok := child.(*parser.TypenameContext); ok {
-			if t := ele.Accept(v); t != nil {
-				tc := t.(*types.AntlrColumn)
-				col.DataType = tc.DataType
-				col.StringLength = tc.StringLength
-				col.Scale = tc.Scale
-				col.AutoIncrement = tc.AutoIncrement
+			if t, ok := ele.Accept(v).(*types.AntlrColumn); ok {
+				t.Name, t.Quoted = col.Name, col.Quoted
+				col = t
+			}
+			if ut := v.lookupType(ele); ut != nil {
+				for _, check := range ut.checks {
+					v.Table.Constraints = append(v.Table.Constraints, &types.Constraint{
+						Name:    check.name,
+						Type:    types.Check,
+						Columns: []string{col.Name},
+						Check:   check.on(col.Name),
+					})
+				}
 			}
 			continue
 		}
+		if ele, ok := child.(*parser.ColquallistContext); ok {
+			v.setColumnConstraints(col, ele)
+		}
 	}
 
+	// the database encoding is not part of the ddl, assume utf-8
+	resolveStringLength(col, "", 0)
+	col.Comment = inlineComment(v.comments, ctx)
+
 	v.Table.Columns = append(v.Table.Columns, col)
 	return nil
 }
 
+// setColumnConstraints applies the collation and constraints of the column.
+func (v *PgVisitor) setColumnConstraints(col *types.AntlrColumn, ctx parser.IColquallistContext) {
+	for _, c := range ctx.AllColconstraint() {
+		if c.COLLATE() != nil && c.Any_name() != nil {
+			col.Collation, _ = normalizeIdentifier(types.PostgreSQL, c.Any_name().GetText())
+			continue
+		}
+		if c.Colconstraintelem() != nil {
+			name := ""
+			if c.Name() != nil {
+				name, _ = normalizeIdentifier(types.PostgreSQL, c.Name().GetText())
+			}
+			v.setColumnConstraint(col, c.Colconstraintelem(), name)
+		}
+	}
+}
+
+// setColumnConstraint applies a column constraint: NOT NULL, DEFAULT, identity, generated
+// expression, and the keys and checks recorded as table constraints of the column.
+func (v *PgVisitor) setColumnConstraint(col *types.AntlrColumn, ctx parser.IColconstraintelemContext, name string) {
+	constraint := &types.Constraint{Name: name, Columns: []string{col.Name}}
+	switch {
+	case ctx.NULL_P() != nil:
+		col.NotNull = ctx.NOT() != nil
+		return
+	case ctx.GENERATED() != nil && ctx.IDENTITY_P() != nil:
+		v.setIdentity(col, ctx.Generated_when(), ctx.Optparenthesizedseqoptlist())
+		return
+	case ctx.GENERATED() != nil && ctx.A_expr() != nil:
+		col.Generated = &types.Generated{Expression: getOriginalText(ctx.A_expr()), Stored: ctx.STORED() != nil}
+		return
+	case ctx.DEFAULT() != nil && ctx.B_expr() != nil:
+		v.setDefault(col, ctx.B_expr())
+		return
+	case ctx.PRIMARY() != nil:
+		col.NotNull = true
+		constraint.Type = types.PrimaryKey
+	case ctx.UNIQUE() != nil:
+		constraint.Type = types.Unique
+	case ctx.CHECK() != nil && ctx.A_expr() != nil:
+		constraint.Type = types.Check
+		constraint.Check = getOriginalText(ctx.A_expr())
+	case ctx.REFERENCES() != nil:
+		constraint.Type = types.ForeignKey
+		constraint.References = v.getReference(ctx.Qualified_name(), ctx.Opt_column_list(), ctx.Key_actions())
+	default:
+		return
+	}
+	v.Table.Constraints = append(v.Table.Constraints, constraint)
+}
+
+// setIdentity makes the column a GENERATED {ALWAYS|BY DEFAULT} AS IDENTITY [(seq options)] column.
+func (v *PgVisitor) setIdentity(col *types.AntlrColumn, when parser.IGenerated_whenContext, opts parser.IOptparenthesizedseqoptlistContext) {
+	col.AutoIncrement = true
+	col.Identity = newIdentity(when == nil || when.ALWAYS() == nil)
+	if opts != nil && opts.Seqoptlist() != nil {
+		v.setSequenceOptions(col.Identity, opts.Seqoptlist())
+	}
+}
+
+// setDefault sets the default expression, DEFAULT nextval('seq') makes the column auto increment.
+func (v *PgVisitor) setDefault(col *types.AntlrColumn, expr antlr.ParserRuleContext) {
+	col.Default = getOriginalText(expr)
+	matches := pgNextvalRegexp.FindStringSubmatch(expr.GetText())
+	if matches == nil {
+		return
+	}
+	col.AutoIncrement = true
+	if col.Identity == nil {
+		col.Identity = newIdentity(true)
+	}
+	// the sequence is a string literal holding a possibly qualified and quoted name
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, matches[1])
+	col.Identity.Sequence = strings.Join(parts, ".")
+}
+
+// addTableConstraint adds a PRIMARY KEY, UNIQUE, CHECK or FOREIGN KEY table constraint,
+// EXCLUDE constraints are skipped.
+func (v *PgVisitor) addTableConstraint(ctx parser.ITableconstraintContext) {
+	elem := ctx.Constraintelem()
+	constraint := &types.Constraint{}
+	if ctx.Name() != nil {
+		constraint.Name, _ = normalizeIdentifier(types.PostgreSQL, ctx.Name().GetText())
+	}
+	switch {
+	case elem.CHECK() != nil:
+		constraint.Type = types.Check
+		constraint.Check = getOriginalText(elem.A_expr())
+	case elem.PRIMARY() != nil:
+		constraint.Type = types.PrimaryKey
+		constraint.Columns = pgColumnList(elem.Columnlist())
+		for _, name := range constraint.Columns {
+			if i := pgColumnIndex(v.Table.Columns, name); i >= 0 {
+				v.Table.Columns[i].NotNull = true
+			}
+		}
+	case elem.UNIQUE() != nil:
+		constraint.Type = types.Unique
+		constraint.Columns = pgColumnList(elem.Columnlist())
+	case elem.FOREIGN() != nil:
+		constraint.Type = types.ForeignKey
+		constraint.Columns = pgColumnList(elem.Columnlist())
+		constraint.References = v.getReference(elem.Qualified_name(), elem.Opt_column_list(), elem.Key_actions())
+	default:
+		return
+	}
+	v.Table.Constraints = append(v.Table.Constraints, constraint)
+}
+
+// getReference returns the referenced table and columns with the ON DELETE / ON UPDATE actions.
+func (v *PgVisitor) getReference(table parser.IQualified_nameContext, columns parser.IOpt_column_listContext, actions parser.IKey_actionsContext) *types.Reference {
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, table.GetText())
+	reference := &types.Reference{Table: strings.Join(parts, ".")}
+	if columns != nil {
+		reference.Columns = pgColumnList(columns.Columnlist())
+	}
+	if actions != nil {
+		if actions.Key_delete() != nil {
+			reference.OnDelete = pgKeyAction(actions.Key_delete().Key_action())
+		}
+		if actions.Key_update() != nil {
+			reference.OnUpdate = pgKeyAction(actions.Key_update().Key_action())
+		}
+	}
+	return reference
+}
+
+func pgKeyAction(ctx parser.IKey_actionContext) string {
+	return strings.ToUpper(strings.Join(strings.Fields(getOriginalText(ctx)), " "))
+}
+
+func pgColumnList(ctx parser.IColumnlistContext) []string {
+	if ctx == nil {
+		return nil
+	}
+	var names []string
+	for _, e := range ctx.AllColumnElem() {
+		name, _ := normalizeIdentifier(types.PostgreSQL, e.GetText())
+		names = append(names, name)
+	}
+	return names
+}
+
+// VisitAltertablestmt applies an ALTER TABLE statement about the table: added columns and
+// constraints, defaults, NOT NULL, identities, and the bound of an attached partition.
+func (v *PgVisitor) VisitAltertablestmt(ctx *parser.AltertablestmtContext) interface{} {
+	if ctx.TABLE() == nil || ctx.Relation_expr() == nil {
+		return nil
+	}
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, ctx.Relation_expr().Qualified_name().GetText())
+
+	if cmd := ctx.Partition_cmd(); cmd != nil {
+		// ALTER TABLE parent ATTACH PARTITION table FOR VALUES ...
+		child, _ := normalizeQualifiedName(types.PostgreSQL, cmd.Qualified_name().GetText())
+		if cmd.ATTACH() != nil && pgIsTable(v.Table, child) {
+			v.postgresTable().PartitionOf = strings.Join(parts, ".")
+			v.postgresTable().PartitionBound = v.getPartitionBound(cmd.Partitionboundspec())
+		}
+		return nil
+	}
+	if !pgIsTable(v.Table, parts) || ctx.Alter_table_cmds() == nil {
+		return nil
+	}
+	for _, cmd := range ctx.Alter_table_cmds().AllAlter_table_cmd() {
+		v.applyAlterTableCmd(cmd)
+	}
+	return nil
+}
+
+func (v *PgVisitor) applyAlterTableCmd(ctx parser.IAlter_table_cmdContext) {
+	switch {
+	case ctx.ColumnDef() != nil:
+		ctx.ColumnDef().Accept(v)
+		return
+	case ctx.Tableconstraint() != nil:
+		v.addTableConstraint(ctx.Tableconstraint())
+		return
+	case ctx.Colid() == nil:
+		return
+	}
+
+	name, _ := normalizeIdentifier(types.PostgreSQL, ctx.Colid().GetText())
+	i := pgColumnIndex(v.Table.Columns, name)
+	if i < 0 {
+		return
+	}
+	col := v.Table.Columns[i]
+	switch {
+	case ctx.ALTER() == nil && ctx.DROP() != nil:
+		// DROP COLUMN
+		v.Table.Columns = append(v.Table.Columns[:i], v.Table.Columns[i+1:]...)
+	case ctx.Alter_column_default() != nil:
+		if d := ctx.Alter_column_default(); d.SET() != nil {
+			v.setDefault(col, d.A_expr())
+		} else {
+			col.Default = ""
+		}
+	case ctx.GENERATED() != nil && ctx.IDENTITY_P() != nil:
+		v.setIdentity(col, ctx.Generated_when(), ctx.Optparenthesizedseqoptlist())
+	case ctx.NOT() != nil && ctx.NULL_P() != nil:
+		col.NotNull = ctx.SET() != nil
+	}
+}
+
+// setSequenceOptions sets the seed and increment from a sequence option list.
+func (v *PgVisitor) setSequenceOptions(identity *types.Identity, ctx parser.ISeqoptlistContext) {
+	for _, opt := range ctx.AllSeqoptelem() {
+		if opt.SEQUENCE() != nil && opt.Any_name() != nil {
+			parts, _ := normalizeQualifiedName(types.PostgreSQL, opt.Any_name().GetText())
+			identity.Sequence = strings.Join(parts, ".")
+			continue
+		}
+		if opt.Numericonly() == nil {
+			continue
+		}
+		n, err := strconv.ParseInt(opt.Numericonly().GetText(), 10, 64)
+		if err != nil {
+			continue
+		}
+		if opt.START() != nil {
+			identity.Seed = n
+		} else if opt.INCREMENT() != nil {
+			identity.Increment = n
+		}
+	}
+}
+
+func (v *PgVisitor) VisitCreateseqstmt(ctx *parser.CreateseqstmtContext) interface{} {
+	if ctx.Qualified_name() == nil {
+		v.Err = errors.New("sequence name is nil")
+		return nil
+	}
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, ctx.Qualified_name().GetText())
+	v.Column = &types.AntlrColumn{
+		Name:     strings.Join(parts, "."),
+		Identity: newIdentity(true),
+	}
+	if ctx.Optseqoptlist() != nil && ctx.Optseqoptlist().Seqoptlist() != nil {
+		v.setSequenceOptions(v.Column.Identity, ctx.Optseqoptlist().Seqoptlist())
+	}
+	return nil
+}
+
 func (v *PgVisitor) VisitColid(ctx *parser.ColidContext) interface{} {
-	return strings.Trim(ctx.Identifier().GetText(), "\"")
+	name, _ := normalizeIdentifier(types.PostgreSQL, ctx.GetText())
+	return name
 }
 
 func (v *PgVisitor) VisitTypename(ctx *parser.TypenameContext) interface{} {
-	fullname := strings.ToLower(ctx.GetText())
-	col, err := v.parseColumnType(fullname)
+	col, err := v.parseColumnType(ctx)
 	if err != nil {
 		v.Err = err
 		return nil
@@ -150,52 +696,351 @@ func (v *PgVisitor) VisitTypename(ctx *parser.TypenameContext) interface{} {
 	return col
 }
 
-func (v *PgVisitor) VisitCommentstmt(ctx *parser.CommentstmtContext) interface{} {
-	arr := strings.Split(ctx.Any_name().GetText(), ".")
+// VisitCreatedomainstmt registers the domain with its base type, NOT NULL, DEFAULT and CHECK
+// constraints, a domain over another domain inherits its checks.
+func (v *PgVisitor) VisitCreatedomainstmt(ctx *parser.CreatedomainstmtContext) interface{} {
+	column, err := v.parseColumnType(ctx.Typename())
+	if err != nil {
+		v.registerType(ctx.Any_name().GetText(), &pgType{err: err})
+		return nil
+	}
+	t := &pgType{column: column}
+	if base := v.lookupType(ctx.Typename()); base != nil {
+		t.checks = append(t.checks, base.checks...)
+	}
 
-	if ctx.COLUMN() != nil {
-		v.Column = &types.AntlrColumn{
-			Name:    strings.Trim(arr[len(arr)-1], "\""),
-			Comment: strings.Trim(ctx.Comment_text().GetText(), "'"),
+	for _, c := range ctx.Colquallist().AllColconstraint() {
+		if c.COLLATE() != nil && c.Any_name() != nil {
+			column.Collation, _ = normalizeIdentifier(types.PostgreSQL, c.Any_name().GetText())
+			continue
+		}
+		elem := c.Colconstraintelem()
+		if elem == nil {
+			continue
+		}
+		switch {
+		case elem.NOT() != nil && elem.NULL_P() != nil:
+			column.NotNull = true
+		case elem.CHECK() != nil && elem.A_expr() != nil:
+			check := newPgCheck(elem.A_expr())
+			if c.Name() != nil {
+				check.name, _ = normalizeIdentifier(types.PostgreSQL, c.Name().GetText())
+			}
+			t.checks = append(t.checks, check)
+		case elem.DEFAULT() != nil && elem.B_expr() != nil:
+			column.Default = getOriginalText(elem.B_expr())
 		}
 	}
+	resolveStringLength(column, "", 0)
+	v.registerType(ctx.Any_name().GetText(), t)
+	return nil
+}
 
-	if ctx.Object_type_any_name() != nil {
-		if _, ok := ctx.Object_type_any_name().(*parser.Object_type_any_nameContext); ok {
-			v.Table.Comment = strings.Trim(ctx.Comment_text().GetText(), "'")
+// newPgCheck reads the CHECK expression of a domain and finds the VALUE references in its tree,
+// a column reference named value.
+func newPgCheck(expr antlr.ParserRuleContext) *pgCheck {
+	check := &pgCheck{text: getOriginalText(expr)}
+	offset := expr.GetStart().GetStart()
+	var walk func(tree antlr.Tree)
+	walk = func(tree antlr.Tree) {
+		if ref, ok := tree.(*parser.ColumnrefContext); ok && ref.Indirection() == nil {
+			if name, _ := normalizeIdentifier(types.PostgreSQL, ref.Colid().GetText()); name == "value" {
+				check.values = append(check.values, [2]int{ref.GetStart().GetStart() - offset, ref.GetStop().GetStop() + 1 - offset})
+				return
+			}
 		}
+		for _, child := range tree.GetChildren() {
+			walk(child)
+		}
+	}
+	walk(expr)
+	return check
+}
+
+// on returns the check expression on the column, VALUE replaced by the quoted column name.
+func (c *pgCheck) on(column string) string {
+	text := []rune(c.text)
+	quoted := `"` + strings.ReplaceAll(column, `"`, `""`) + `"`
+	var sb strings.Builder
+	last := 0
+	for _, r := range c.values {
+		sb.WriteString(string(text[last:r[0]]))
+		sb.WriteString(quoted)
+		last = r[1]
+	}
+	sb.WriteString(string(text[last:]))
+	return sb.String()
+}
+
+// VisitDefinestmt registers the enum, composite, range and base types of CREATE TYPE.
+func (v *PgVisitor) VisitDefinestmt(ctx *parser.DefinestmtContext) interface{} {
+	if ctx.TYPE_P() == nil || ctx.Any_name(0) == nil {
+		return nil
 	}
 
+	column := &types.AntlrColumn{}
+	switch {
+	case ctx.ENUM_P() != nil:
+		column.DataType = types.String
+		if ctx.Opt_enum_val_list() != nil {
+			for _, s := range ctx.Opt_enum_val_list().Enum_val_list().AllSconst() {
+				value := decodeString(types.PostgreSQL, s.GetText())
+				column.Values = append(column.Values, value)
+				column.CharLength = max(column.CharLength, len([]rune(value)))
+			}
+		}
+		column.StringLength = If(column.CharLength < 50, column.CharLength, 50)
+	case ctx.AS() != nil && ctx.RANGE() == nil:
+		column.DataType = types.Struct
+		if ctx.Opttablefuncelementlist() != nil {
+			for _, e := range ctx.Opttablefuncelementlist().Tablefuncelementlist().AllTablefuncelement() {
+				field, err := v.parseColumnType(e.Typename())
+				if err != nil {
+					v.registerType(ctx.Any_name(0).GetText(), &pgType{err: err})
+					return nil
+				}
+				field.Name, field.Quoted = normalizeIdentifier(types.PostgreSQL, e.Colid().GetText())
+				resolveStringLength(field, "", 0)
+				column.Fields = append(column.Fields, field)
+			}
+		}
+	default:
+		// ranges and base types are written in their text representation
+		column.DataType = types.String
+		column.StringLength = 50
+	}
+	resolveStringLength(column, "", 0)
+	v.registerType(ctx.Any_name(0).GetText(), &pgType{column: column})
 	return nil
 }
 
-// extractColumnTypeInfo extracts the column type information using regular expressions.
-func (v *PgVisitor) extractColumnTypeInfo(dataType string) (originalType string, length int, scale int, err error) {
-	re := regexp.MustCompile(`(?P<DataType>\w+)(?:\((?P<StringLength>\d+)(?:,\s*(?P<Scale>\d+))?\))?`)
-	matches := re.FindStringSubmatch(dataType)
-	if matches == nil || len(matches) < 2 {
-		return "", 0, 0, fmt.Errorf("invalid data type format: %s", dataType)
+// lookupType returns the script declared type of the type name, built-in types take precedence
+// as pg_catalog is searched first.
+func (v *PgVisitor) lookupType(ctx parser.ITypenameContext) *pgType {
+	originalType, _, _, err := v.extractColumnTypeInfo(ctx)
+	if err != nil {
+		return nil
 	}
+	if _, ok := v.typeMap()[originalType]; ok {
+		return nil
+	}
+	// originalType is normalized, a qualified name falls back to a type of the same name
+	// declared in another schema, the search path is not known
+	if t, ok := v.userTypes[originalType]; ok {
+		return t
+	}
+	return v.userTypes[originalType[strings.LastIndex(originalType, ".")+1:]]
+}
 
-	originalType = strings.ToLower(matches[1])
-	if len(matches) >= 3 && matches[2] != "" {
-		length, err = strconv.Atoi(matches[2])
-		if err != nil {
-			return "", 0, 0, err
+// registerType declares the type under its normalized, possibly schema qualified, name. The
+// unqualified name refers to the first schema declaring it unless declared without schema.
+func (v *PgVisitor) registerType(name string, t *pgType) {
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, name)
+	v.userTypes[strings.Join(parts, ".")] = t
+	unqualified := parts[len(parts)-1]
+	if _, ok := v.userTypes[unqualified]; !ok {
+		v.userTypes[unqualified] = t
+	}
+}
+
+// VisitCommentstmt sets the comment of COMMENT ON TABLE and COMMENT ON COLUMN statements
+// about the table.
+func (v *PgVisitor) VisitCommentstmt(ctx *parser.CommentstmtContext) interface{} {
+	if ctx.Any_name() == nil {
+		return nil
+	}
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, ctx.Any_name().GetText())
+
+	switch {
+	case ctx.COLUMN() != nil:
+		if len(parts) > 1 && !pgIsTable(v.Table, parts[:len(parts)-1]) {
+			return nil
+		}
+		if i := pgColumnIndex(v.Table.Columns, parts[len(parts)-1]); i >= 0 {
+			v.Table.Columns[i].Comment = v.getCommentText(ctx.Comment_text())
+		}
+	case ctx.Object_type_any_name() != nil && ctx.Object_type_any_name().TABLE() != nil:
+		if pgIsTable(v.Table, parts) {
+			v.Table.Comment = v.getCommentText(ctx.Comment_text())
 		}
 	}
-	if len(matches) >= 4 && matches[3] != "" {
-		scale, err = strconv.Atoi(matches[3])
-		if err != nil {
-			return "", 0, 0, err
+	return nil
+}
+
+// getCommentText decodes the comment string, IS NULL removes the comment.
+func (v *PgVisitor) getCommentText(ctx parser.IComment_textContext) string {
+	if ctx.NULL_P() != nil {
+		return ""
+	}
+	return decodeString(types.PostgreSQL, ctx.GetText())
+}
+
+// extractColumnTypeInfo walks the type name and returns its canonical name, as printed by
+// format_type, with the length and scale modifiers, e.g. "character varying(20)", "varchar(20)"
+// -> character varying, 20. Arrays are reported as their element type.
+func (v *PgVisitor) extractColumnTypeInfo(ctx parser.ITypenameContext) (originalType string, length int, scale int, err error) {
+	st := ctx.Simpletypename()
+	if st == nil {
+		return "", 0, 0, fmt.Errorf("unsupported data type: %s", ctx.GetText())
+	}
+
+	var mods []int
+	switch {
+	case st.Generictype() != nil:
+		originalType, mods = v.getGenericType(st.Generictype())
+	case st.Numeric() != nil:
+		originalType, mods = v.getNumericType(st.Numeric())
+	case st.Bit() != nil:
+		originalType, mods = v.getBitType(st.Bit())
+	case st.Character() != nil:
+		originalType = "character"
+		if c := st.Character().Character_c(); c.VARCHAR() != nil || c.Opt_varying() != nil {
+			originalType = "character varying"
 		}
+		if st.Character().Iconst() != nil {
+			mods = []int{pgIconst(st.Character().Iconst())}
+		}
+	case st.Constdatetime() != nil:
+		dt := st.Constdatetime()
+		originalType = If(dt.TIMESTAMP() != nil, "timestamp", "time")
+		if tz := dt.Opt_timezone(); tz != nil && tz.WITH() != nil {
+			originalType += " with time zone"
+		} else {
+			originalType += " without time zone"
+		}
+		if dt.Iconst() != nil {
+			mods = []int{pgIconst(dt.Iconst())}
+		}
+	case st.Constinterval() != nil:
+		originalType = "interval"
+	}
+
+	if len(mods) > 0 {
+		length = mods[0]
+	}
+	if len(mods) > 1 {
+		scale = mods[1]
 	}
 	return originalType, length, scale, nil
 }
 
+// getGenericType returns the canonical name of a type written as a plain, possibly schema
+// qualified, name with its modifiers, e.g. int4, pg_catalog.varchar(20) or a user defined type.
+func (v *PgVisitor) getGenericType(ctx parser.IGenerictypeContext) (string, []int) {
+	name := ctx.GetText()
+	var mods []int
+	if m := ctx.Opt_type_modifiers(); m != nil {
+		name = strings.TrimSuffix(name, m.GetText())
+		mods = pgTypeModifiers(m.Expr_list())
+	}
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, name)
+	// KingbaseES renamed pg_catalog to sys_catalog
+	if len(parts) == 2 && (parts[0] == "pg_catalog" || parts[0] == "sys_catalog") {
+		parts = parts[1:]
+	}
+	name = strings.Join(parts, ".")
+	if canonical, ok := pgTypeAliases[name]; ok {
+		return canonical, mods
+	}
+	return name, mods
+}
+
+// getNumericType returns the canonical name of the numeric types written with sql keywords.
+func (v *PgVisitor) getNumericType(ctx parser.INumericContext) (string, []int) {
+	switch {
+	case ctx.SMALLINT() != nil:
+		return "smallint", nil
+	case ctx.INT_P() != nil, ctx.INTEGER() != nil:
+		return "integer", nil
+	case ctx.BIGINT() != nil:
+		return "bigint", nil
+	case ctx.REAL() != nil:
+		return "real", nil
+	case ctx.FLOAT_P() != nil:
+		// FLOAT(p) gives the precision in bits, a double is used above 24
+		if ctx.Opt_float() != nil && pgIconst(ctx.Opt_float().Iconst()) <= 24 {
+			return "real", nil
+		}
+		return "double precision", nil
+	case ctx.DOUBLE_P() != nil:
+		return "double precision", nil
+	case ctx.BOOLEAN_P() != nil:
+		return "boolean", nil
+	}
+	// DECIMAL, DEC and NUMERIC
+	if ctx.Opt_type_modifiers() != nil {
+		return "numeric", pgTypeModifiers(ctx.Opt_type_modifiers().Expr_list())
+	}
+	return "numeric", nil
+}
+
+// getBitType returns the canonical name of BIT [VARYING] [(n)].
+func (v *PgVisitor) getBitType(ctx parser.IBitContext) (string, []int) {
+	if b := ctx.Bitwithlength(); b != nil {
+		return If(b.Opt_varying() != nil, "bit varying", "bit"), pgTypeModifiers(b.Expr_list())
+	}
+	if b := ctx.Bitwithoutlength(); b != nil && b.Opt_varying() != nil {
+		return "bit varying", nil
+	}
+	return "bit", nil
+}
+
+// pgTypeModifiers returns the integer type modifiers, e.g. the precision and scale of numeric.
+func pgTypeModifiers(ctx parser.IExpr_listContext) []int {
+	if ctx == nil {
+		return nil
+	}
+	var mods []int
+	for _, e := range ctx.AllA_expr() {
+		n, _ := strconv.Atoi(e.GetText())
+		mods = append(mods, n)
+	}
+	return mods
+}
+
+func pgIconst(ctx parser.IIconstContext) int {
+	if ctx == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(ctx.GetText())
+	return n
+}
+
+// pgTypeAliases maps the internal and alternative names of the built-in types to the
+// canonical name used in PgTypeMap.
+var pgTypeAliases = map[string]string{
+	"int2":        "smallint",
+	"int4":        "integer",
+	"int":         "integer",
+	"int8":        "bigint",
+	"serial2":     "smallserial",
+	"serial4":     "serial",
+	"serial8":     "bigserial",
+	"float4":      "real",
+	"float8":      "double precision",
+	"decimal":     "numeric",
+	"bool":        "boolean",
+	"varchar":     "character varying",
+	"char":        "character",
+	"bpchar":      "character",
+	"varbit":      "bit varying",
+	"timestamp":   "timestamp without time zone",
+	"timestamptz": "timestamp with time zone",
+	"time":        "time without time zone",
+	"timetz":      "time with time zone",
+}
+
+// typeMap returns the built-in types of the dialect of the script.
+func (v *PgVisitor) typeMap() map[string]types.DbType {
+	if v.script != nil && v.script.dialect == types.KingbaseES {
+		return types.KingbaseTypeMap
+	}
+	return types.PgTypeMap
+}
+
 // mapColumnType maps the original type to a simplified type.
 func (v *PgVisitor) mapColumnType(originalType string) (string, error) {
-	simplifiedType, exists := types.PgTypeMap[originalType]
+	simplifiedType, exists := v.typeMap()[originalType]
 	if !exists {
 		return "", fmt.Errorf("unsupported data type: %s", originalType)
 	}
@@ -205,36 +1050,80 @@ func (v *PgVisitor) mapColumnType(originalType string) (string, error) {
 // setColumnAttributes sets the attributes of the column based on its type.
 func (v *PgVisitor) setColumnAttributes(column *types.AntlrColumn, originalType string, length int, scale int) {
 	switch originalType {
-	case "int2", "smallint":
+	case "smallint":
 		column.MaxInteger = math.MaxInt16
-	case "int4", "integer":
+	case "integer":
 		column.MaxInteger = math.MaxInt32
-	case "int8", "bigint":
+	case "bigint":
 		column.MaxInteger = math.MaxInt64
+	case "tinyint":
+		column.MaxInteger = math.MaxInt8
+	case "mediumint":
+		column.MaxInteger = 1<<23 - 1
+	case "oid", "xid", "cid":
+		column.MaxInteger = math.MaxUint32
+	case "smallserial":
+		column.MaxInteger = math.MaxInt16
+		column.AutoIncrement = true
+		column.Identity = newIdentity(true)
 	case "serial":
 		column.MaxInteger = math.MaxInt32
 		column.AutoIncrement = true
+		column.Identity = newIdentity(true)
 	case "bigserial":
 		column.MaxInteger = math.MaxInt64
 		column.AutoIncrement = true
-	case "varchar", "char", "text":
+		column.Identity = newIdentity(true)
+	case "character varying", "character", "text", "bit", "bit varying", "varchar2", "nvarchar2", "nvarchar", "nchar":
 		column.StringLength = If(length > 0 && length < 50, length, 50)
-	case "numeric", "decimal", "double":
+		fixed := originalType == "character" || originalType == "bit" || originalType == "nchar"
+		column.CharLength = If(fixed && length == 0, 1, length)
+	case "bytea", "clob", "nclob", "long", "blob", "raw":
+		column.StringLength = 50
+	case "uuid", "inet", "cidr", "macaddr", "macaddr8":
+		// the longest text representation
+		column.CharLength = pgTextLengths[originalType]
+		column.StringLength = If(column.CharLength < 50, column.CharLength, 50)
+	case "numeric", "number", "double precision":
 		column.MaxFloat = getMaxFloat64(length)
 		column.Scale = If(scale > 0, scale, 2)
+	case "money":
+		column.MaxFloat = getMaxFloat64(17) // 92233720368547758.07
+		column.Scale = 2
 	case "real":
 		column.MaxFloat = getMaxFloat32(length)
 		column.Scale = If(scale > 0, scale, 2)
+	case "binary_float":
+		column.MaxFloat = math.MaxFloat32
+		column.Scale = 2
+	case "binary_double":
+		column.MaxFloat = math.MaxFloat64
+		column.Scale = 2
 	}
 }
 
+var pgTextLengths = map[string]int{
+	"uuid":     36,
+	"inet":     43,
+	"cidr":     43,
+	"macaddr":  17,
+	"macaddr8": 23,
+}
+
 // parseColumnType parses the column type definition and returns an AntlrColumn.
-func (v *PgVisitor) parseColumnType(dataType string) (*types.AntlrColumn, error) {
-	originalType, length, scale, err := v.extractColumnTypeInfo(dataType)
+func (v *PgVisitor) parseColumnType(ctx parser.ITypenameContext) (*types.AntlrColumn, error) {
+	originalType, length, scale, err := v.extractColumnTypeInfo(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if t := v.lookupType(ctx); t != nil {
+		if t.err != nil {
+			return nil, t.err
+		}
+		return clonePgColumn(t.column), nil
+	}
+
 	simplifiedType, err := v.mapColumnType(originalType)
 	if err != nil {
 		return nil, err
@@ -245,7 +1134,8 @@ func (v *PgVisitor) parseColumnType(dataType string) (*types.AntlrColumn, error)
 	return column, nil
 }
 
-func parsePgColumnComment(sql string) (*types.AntlrColumn, error) {
+// parsePgComment parses a COMMENT ON statement, the comment is set when it is about the table.
+func parsePgComment(sql string, table *types.AntlrTable) error {
 	lexer := parser.NewPostgreSQLLexer(antlr.NewInputStream(sql))
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 
@@ -253,6 +1143,65 @@ func parsePgColumnComment(sql string) (*types.AntlrColumn, error) {
 	p.BuildParseTrees = true
 
 	tree := p.Commentstmt()
+	visitor := &PgVisitor{
+		BasePostgreSQLParserVisitor: &parser.BasePostgreSQLParserVisitor{},
+		Table:                       table,
+	}
+	tree.Accept(visitor)
+	return visitor.Err
+}
+
+// parsePgAlterTable parses an ALTER TABLE statement, it is applied when it is about the table.
+func parsePgAlterTable(sql string, table *types.AntlrTable, script *pgScript) error {
+	lexer := parser.NewPostgreSQLLexer(antlr.NewInputStream(sql))
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+
+	p := parser.NewPostgreSQLParser(stream)
+	p.BuildParseTrees = true
+
+	tree := p.Altertablestmt()
+	visitor := &PgVisitor{
+		BasePostgreSQLParserVisitor: &parser.BasePostgreSQLParserVisitor{},
+		Table:                       table,
+		userTypes:                   script.userTypes,
+		script:                      script,
+	}
+	tree.Accept(visitor)
+	return visitor.Err
+}
+
+// parsePgSequenceOwner parses an ALTER SEQUENCE statement and returns the sequence name and
+// the table.column of its OWNED BY option, owner is empty without the option.
+func parsePgSequenceOwner(sql string) (seq string, owner string, err error) {
+	lexer := parser.NewPostgreSQLLexer(antlr.NewInputStream(sql))
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+
+	p := parser.NewPostgreSQLParser(stream)
+	p.BuildParseTrees = true
+
+	tree := p.Alterseqstmt()
+	if tree.Qualified_name() == nil || tree.Seqoptlist() == nil {
+		return "", "", nil
+	}
+	parts, _ := normalizeQualifiedName(types.PostgreSQL, tree.Qualified_name().GetText())
+	for _, opt := range tree.Seqoptlist().AllSeqoptelem() {
+		if opt.OWNED() != nil && opt.Any_name() != nil {
+			owner = opt.Any_name().GetText()
+		}
+	}
+	return strings.Join(parts, "."), owner, nil
+}
+
+// parsePgSequence parses a CREATE SEQUENCE statement, the returned column carries
+// the sequence name and its identity settings.
+func parsePgSequence(sql string) (*types.AntlrColumn, error) {
+	lexer := parser.NewPostgreSQLLexer(antlr.NewInputStream(sql))
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+
+	p := parser.NewPostgreSQLParser(stream)
+	p.BuildParseTrees = true
+
+	tree := p.Createseqstmt()
 	visitor := &PgVisitor{
 		BasePostgreSQLParserVisitor: &parser.BasePostgreSQLParserVisitor{},
 		Column:                      &types.AntlrColumn{},
@@ -277,7 +1226,30 @@ func parsePgTableComment(sql string) (string, error) {
 	return visitor.Table.Comment, visitor.Err
 }
 
-func parsePgTable(sql string) (*types.AntlrTable, error) {
+// parsePgType parses a CREATE DOMAIN or CREATE TYPE statement into the script type registry.
+func parsePgType(sql string, script *pgScript) error {
+	lexer := parser.NewPostgreSQLLexer(antlr.NewInputStream(sql))
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+
+	p := parser.NewPostgreSQLParser(stream)
+	p.BuildParseTrees = true
+
+	var tree antlr.ParseTree
+	if strings.HasPrefix(strings.ToUpper(skipLeadingComments(sql)), "CREATE DOMAIN") {
+		tree = p.Createdomainstmt()
+	} else {
+		tree = p.Definestmt()
+	}
+	visitor := &PgVisitor{
+		BasePostgreSQLParserVisitor: &parser.BasePostgreSQLParserVisitor{},
+		userTypes:                   script.userTypes,
+		script:                      script,
+	}
+	tree.Accept(visitor)
+	return visitor.Err
+}
+
+func parsePgTable(sql string, script *pgScript) (*types.AntlrTable, error) {
 	lexer := parser.NewPostgreSQLLexer(antlr.NewInputStream(sql))
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 
@@ -289,14 +1261,44 @@ func parsePgTable(sql string) (*types.AntlrTable, error) {
 	visitor := &PgVisitor{
 		BasePostgreSQLParserVisitor: &parser.BasePostgreSQLParserVisitor{},
 		Table: &types.AntlrTable{
-			Dialect: types.PostgreSQL,
+			Dialect: script.dialect,
 			Columns: make([]*types.AntlrColumn, 0),
 		},
+		userTypes: script.userTypes,
+		script:    script,
+	}
+	if script.opts.inlineComments {
+		visitor.comments = stream
 	}
 	tree.Accept(visitor)
 	return visitor.Table, visitor.Err
 }
 
+var pgNextvalRegexp = regexp.MustCompile(`(?i)^nextval\('([^']+)'(?:::regclass)?\)$`)
+
+func newIdentity(explicitInsert bool) *types.Identity {
+	return &types.Identity{Seed: 1, Increment: 1, ExplicitInsert: explicitInsert}
+}
+
+// applySequence copies the seed and increment of a sequence onto the columns backed by it.
+// seq is the column returned by a sequence parser, its Name is the sequence name.
+func applySequence(table *types.AntlrTable, seq *types.AntlrColumn) {
+	if seq == nil || seq.Identity == nil {
+		return
+	}
+	name := seq.Name[strings.LastIndex(seq.Name, ".")+1:]
+	for _, c := range table.Columns {
+		if c.Identity == nil || c.Identity.Sequence == "" {
+			continue
+		}
+		s := c.Identity.Sequence[strings.LastIndex(c.Identity.Sequence, ".")+1:]
+		if identifierEqual(table.Dialect, s, name) {
+			c.Identity.Seed = seq.Identity.Seed
+			c.Identity.Increment = seq.Identity.Increment
+		}
+	}
+}
+
 func getMaxFloat64(length int) float64 {
 	if length == 0 {
 		length = 18
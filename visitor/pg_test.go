@@ -0,0 +1,412 @@
+package visitor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/aierdong/createtable-sql-parser/types"
+)
+
+func TestParsePgDomainCheck(t *testing.T) {
+	table, err := ParsePgSql(`CREATE DOMAIN public.email AS varchar(100) CONSTRAINT email_format CHECK (VALUE ~ '^.+@.+$');
+CREATE DOMAIN work_email AS email CHECK (value LIKE '%@example.com' AND length(VALUE) > 0);
+CREATE TABLE users (
+    id integer,
+    "Contact" work_email NOT NULL,
+    value integer CHECK (value > 0)
+);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*types.Constraint{
+		{Name: "email_format", Type: types.Check, Columns: []string{"Contact"}, Check: `"Contact" ~ '^.+@.+$'`},
+		{Type: types.Check, Columns: []string{"Contact"}, Check: `"Contact" LIKE '%@example.com' AND length("Contact") > 0`},
+		{Type: types.Check, Columns: []string{"value"}, Check: "value > 0"},
+	}
+	if !reflect.DeepEqual(table.Constraints, want) {
+		t.Errorf("constraints = %+v, want %+v", table.Constraints, want)
+	}
+	if c := table.Columns[1]; c.DataType != types.String || c.CharLength != 100 || !c.NotNull {
+		t.Errorf("Contact: DataType = %s, CharLength = %d, NotNull = %v, want varchar(100) NOT NULL", c.DataType, c.CharLength, c.NotNull)
+	}
+}
+
+func TestParsePgSerialSequence(t *testing.T) {
+	table, err := ParsePgSql(`CREATE TABLE public.orders (id serial, code bigint GENERATED ALWAYS AS IDENTITY);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range table.Columns {
+		if !c.AutoIncrement || c.Identity == nil || c.Identity.Sequence != "" {
+			t.Errorf("%s: AutoIncrement = %v, Identity = %+v, want an unnamed sequence", c.Name, c.AutoIncrement, c.Identity)
+		}
+	}
+
+	// pg_dump names the sequence of a serial column
+	table, err = ParsePgSql(`CREATE TABLE public.orders (id integer NOT NULL);
+CREATE SEQUENCE public.orders_id_seq AS integer START WITH 100 INCREMENT BY 1;
+ALTER SEQUENCE public.orders_id_seq OWNED BY public.orders.id;
+ALTER TABLE ONLY public.orders ALTER COLUMN id SET DEFAULT nextval('public.orders_id_seq'::regclass);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := table.Columns[0]
+	if !id.AutoIncrement || id.Identity == nil || id.Identity.Sequence != "public.orders_id_seq" || id.Identity.Seed != 100 {
+		t.Errorf("id: AutoIncrement = %v, Identity = %+v, want public.orders_id_seq from 100", id.AutoIncrement, id.Identity)
+	}
+}
+
+func TestParsePgIdentifierCase(t *testing.T) {
+	table, err := ParsePgSql(`CREATE TABLE Public."Orders" (Id integer, "Name" text, "a""b" text);
+COMMENT ON TABLE public."Orders" IS 'orders';
+COMMENT ON COLUMN public."Orders".ID IS 'id';
+COMMENT ON COLUMN "Orders".name IS 'folded to name, not Name';
+COMMENT ON COLUMN "Orders"."a""b" IS 'escaped';
+COMMENT ON TABLE public.orders IS 'another table';`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Schema != "public" || table.Name != "Orders" || !table.Quoted || table.Comment != "orders" {
+		t.Errorf("table = %s.%s, Quoted = %v, Comment = %q, want public.Orders quoted", table.Schema, table.Name, table.Quoted, table.Comment)
+	}
+	tests := []struct {
+		name    string
+		quoted  bool
+		comment string
+	}{
+		{"id", false, "id"},
+		{"Name", true, ""},
+		{`a"b`, true, "escaped"},
+	}
+	for i, tt := range tests {
+		c := table.Columns[i]
+		if c.Name != tt.name || c.Quoted != tt.quoted || c.Comment != tt.comment {
+			t.Errorf("column %d = %q, Quoted = %v, Comment = %q, want %q, %v, %q", i, c.Name, c.Quoted, c.Comment, tt.name, tt.quoted, tt.comment)
+		}
+	}
+}
+
+func TestParsePgComment(t *testing.T) {
+	table, err := ParsePgSql(`CREATE TABLE t (a integer, b text, c text);
+COMMENT ON TABLE t IS E'it\'s\na table';
+COMMENT ON COLUMN t.a IS 'it''s';
+COMMENT ON COLUMN t.b IS $$dollar 'quoted'$$;
+COMMENT ON COLUMN t.c IS U&'caf\00e9';`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Comment != "it's\na table" {
+		t.Errorf("table comment = %q, want %q", table.Comment, "it's\na table")
+	}
+	want := []string{"it's", "dollar 'quoted'", "café"}
+	for i, c := range table.Columns {
+		if c.Comment != want[i] {
+			t.Errorf("%s: comment = %q, want %q", c.Name, c.Comment, want[i])
+		}
+	}
+}
+
+func TestParsePgTypes(t *testing.T) {
+	table, err := ParsePgSql(`CREATE TABLE t (
+    a double precision,
+    b character varying(20),
+    c varchar(20),
+    d timestamp without time zone,
+    e timestamp(3) with time zone,
+    f time,
+    g bit varying(8),
+    h bit,
+    i money,
+    j inet,
+    k tsvector,
+    l int4range,
+    m smallserial,
+    n int8,
+    o pg_catalog.int4,
+    p float(10),
+    q integer[],
+    r char,
+    s interval day to second,
+    u uuid,
+    v macaddr,
+    w numeric(10,3)
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		dataType      types.DbType
+		maxInteger    int64
+		charLength    int
+		autoIncrement bool
+	}{
+		{"a", types.Numeric, 0, 0, false},
+		{"b", types.String, 0, 20, false},
+		{"c", types.String, 0, 20, false},
+		{"d", types.DateTime, 0, 0, false},
+		{"e", types.DateTime, 0, 0, false},
+		{"f", types.Time, 0, 0, false},
+		{"g", types.String, 0, 8, false},
+		{"h", types.String, 0, 1, false},
+		{"i", types.Numeric, 0, 0, false},
+		{"j", types.String, 0, 43, false},
+		{"k", types.String, 0, 0, false},
+		{"l", types.String, 0, 0, false},
+		{"m", types.Integer, math.MaxInt16, 0, true},
+		{"n", types.Integer, math.MaxInt64, 0, false},
+		{"o", types.Integer, math.MaxInt32, 0, false},
+		{"p", types.Numeric, 0, 0, false},
+		{"q", types.Integer, math.MaxInt32, 0, false},
+		{"r", types.Char, 0, 1, false},
+		{"s", types.String, 0, 0, false},
+		{"u", types.String, 0, 36, false},
+		{"v", types.String, 0, 17, false},
+		{"w", types.Numeric, 0, 0, false},
+	}
+	if len(table.Columns) != len(tests) {
+		t.Fatalf("columns = %d, want %d", len(table.Columns), len(tests))
+	}
+	for i, tt := range tests {
+		c := table.Columns[i]
+		if c.Name != tt.name || c.DataType != tt.dataType || c.MaxInteger != tt.maxInteger || c.CharLength != tt.charLength || c.AutoIncrement != tt.autoIncrement {
+			t.Errorf("column %s: DataType = %s, MaxInteger = %d, CharLength = %d, AutoIncrement = %v, want %s, %d, %d, %v",
+				c.Name, c.DataType, c.MaxInteger, c.CharLength, c.AutoIncrement, tt.dataType, tt.maxInteger, tt.charLength, tt.autoIncrement)
+		}
+	}
+	if i := table.Columns[8]; i.MaxFloat != getMaxFloat64(17) || i.Scale != 2 {
+		t.Errorf("i: MaxFloat = %v, Scale = %d, want money", i.MaxFloat, i.Scale)
+	}
+	if p := table.Columns[15]; p.MaxFloat != getMaxFloat32(0) {
+		t.Errorf("p: MaxFloat = %v, want FLOAT(10) as real", p.MaxFloat)
+	}
+	if w := table.Columns[21]; w.MaxFloat != getMaxFloat64(10) || w.Scale != 3 {
+		t.Errorf("w: MaxFloat = %v, Scale = %d, want numeric(10,3)", w.MaxFloat, w.Scale)
+	}
+
+	if _, err := ParsePgSql(`CREATE TABLE t (a no_such_type)`); err == nil {
+		t.Error("unknown type: want an error")
+	}
+}
+
+func TestParsePgUserTypes(t *testing.T) {
+	table, err := ParsePgSql(`CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy');
+CREATE TYPE public.address AS (street varchar(40), zip integer);
+CREATE TYPE floatrange AS RANGE (subtype = float8);
+CREATE DOMAIN positive_int AS integer NOT NULL DEFAULT 1 CHECK (VALUE > 0);
+CREATE DOMAIN code AS character(3) COLLATE "C";
+CREATE DOMAIN broken AS no_such_type;
+CREATE TABLE t (m mood, a public.address, r floatrange, p positive_int, c code NULL, n mood[]);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Columns) != 6 {
+		t.Fatalf("columns = %d, want 6", len(table.Columns))
+	}
+
+	m := table.Columns[0]
+	if m.DataType != types.String || m.CharLength != 5 || !reflect.DeepEqual(m.Values, []string{"sad", "ok", "happy"}) {
+		t.Errorf("m: DataType = %s, CharLength = %d, Values = %q, want the mood enum", m.DataType, m.CharLength, m.Values)
+	}
+	a := table.Columns[1]
+	if a.DataType != types.Struct || len(a.Fields) != 2 {
+		t.Fatalf("a: DataType = %s, Fields = %d, want the address composite", a.DataType, len(a.Fields))
+	}
+	if f := a.Fields[0]; f.Name != "street" || f.DataType != types.String || f.CharLength != 40 {
+		t.Errorf("a.street: DataType = %s, CharLength = %d, want varchar(40)", f.DataType, f.CharLength)
+	}
+	if f := a.Fields[1]; f.Name != "zip" || f.DataType != types.Integer || f.MaxInteger != math.MaxInt32 {
+		t.Errorf("a.zip: DataType = %s, MaxInteger = %d, want integer", f.DataType, f.MaxInteger)
+	}
+	if r := table.Columns[2]; r.DataType != types.String || r.StringLength != 50 {
+		t.Errorf("r: DataType = %s, StringLength = %d, want a range as text", r.DataType, r.StringLength)
+	}
+	p := table.Columns[3]
+	if p.Name != "p" || p.DataType != types.Integer || p.MaxInteger != math.MaxInt32 || !p.NotNull || p.Default != "1" {
+		t.Errorf("p: DataType = %s, MaxInteger = %d, NotNull = %v, Default = %q, want the positive_int domain",
+			p.DataType, p.MaxInteger, p.NotNull, p.Default)
+	}
+	if c := table.Columns[4]; c.DataType != types.Char || c.CharLength != 3 || c.Collation != "C" || c.NotNull {
+		t.Errorf("c: DataType = %s, CharLength = %d, Collation = %q, NotNull = %v, want character(3) COLLATE C",
+			c.DataType, c.CharLength, c.Collation, c.NotNull)
+	}
+	if n := table.Columns[5]; n.DataType != types.String || len(n.Values) != 3 {
+		t.Errorf("n: DataType = %s, Values = %q, want a mood array", n.DataType, n.Values)
+	}
+	want := []*types.Constraint{{Type: types.Check, Columns: []string{"p"}, Check: `"p" > 0`}}
+	if !reflect.DeepEqual(table.Constraints, want) {
+		t.Errorf("constraints = %+v, want %+v", table.Constraints, want)
+	}
+
+	// a domain that cannot be resolved only fails the columns using it
+	if _, err := ParsePgSql(`CREATE DOMAIN broken AS no_such_type;
+CREATE TABLE t (a broken);`); err == nil {
+		t.Error("column of an unresolved domain: want an error")
+	}
+}
+
+func TestParsePgPartitionBy(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want *types.Partitioning
+	}{
+		{
+			`CREATE TABLE m (id int, ts timestamp, region text) PARTITION BY RANGE (ts, lower(region));`,
+			&types.Partitioning{Strategy: types.RangePartition, Keys: []string{"ts", "lower(region)"}},
+		},
+		{
+			`CREATE TABLE m (id int, "Region" text) PARTITION BY LIST ("Region");`,
+			&types.Partitioning{Strategy: types.ListPartition, Keys: []string{"Region"}},
+		},
+		{
+			`CREATE TABLE m (id int) PARTITION BY HASH (id);`,
+			&types.Partitioning{Strategy: types.HashPartition, Keys: []string{"id"}},
+		},
+	}
+	for _, tt := range tests {
+		table, err := ParsePgSql(tt.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(table.Partitioning, tt.want) {
+			t.Errorf("%s: Partitioning = %+v, want %+v", tt.sql, table.Partitioning, tt.want)
+		}
+	}
+}
+
+func TestParsePgPartitionOf(t *testing.T) {
+	const parent = `CREATE TABLE public.m (id integer NOT NULL, ts timestamp, region text) PARTITION BY RANGE (ts);`
+	tests := []struct {
+		name   string
+		sql    string
+		parent string
+		bound  *types.PartitionDefinition
+	}{
+		{
+			name:   "range",
+			sql:    `CREATE TABLE m_2024 PARTITION OF public.m FOR VALUES FROM ('2024-01-01') TO (MAXVALUE);`,
+			parent: "public.m",
+			bound:  &types.PartitionDefinition{Name: "m_2024", From: []string{"'2024-01-01'"}, To: []string{"MAXVALUE"}},
+		},
+		{
+			name:   "list",
+			sql:    `CREATE TABLE m_eu PARTITION OF m FOR VALUES IN ('eu', 'uk');`,
+			parent: "m",
+			bound:  &types.PartitionDefinition{Name: "m_eu", In: []string{"'eu'", "'uk'"}},
+		},
+		{
+			name:   "hash",
+			sql:    `CREATE TABLE m_1 PARTITION OF m FOR VALUES WITH (MODULUS 4, REMAINDER 1);`,
+			parent: "m",
+			bound:  &types.PartitionDefinition{Name: "m_1", Modulus: 4, Remainder: 1},
+		},
+		{
+			name:   "default",
+			sql:    `CREATE TABLE m_other PARTITION OF m DEFAULT;`,
+			parent: "m",
+			bound:  &types.PartitionDefinition{Name: "m_other", Default: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the first table of the script is returned, the parent is looked up after it
+			table, err := ParsePgSql(tt.sql + "\n" + parent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if table.Postgres == nil || table.Postgres.PartitionOf != tt.parent {
+				t.Fatalf("Postgres = %+v, want a partition of %s", table.Postgres, tt.parent)
+			}
+			if !reflect.DeepEqual(table.Postgres.PartitionBound, tt.bound) {
+				t.Errorf("PartitionBound = %+v, want %+v", table.Postgres.PartitionBound, tt.bound)
+			}
+			if len(table.Columns) != 3 || table.Columns[0].Name != "id" || !table.Columns[0].NotNull {
+				t.Errorf("columns = %d, want id, ts and region of the parent", len(table.Columns))
+			}
+		})
+	}
+
+	// column options of the partition apply to its copy of the parent columns
+	table, err := ParsePgSql(`CREATE TABLE m_1 PARTITION OF m (ts NOT NULL, CONSTRAINT m_1_pk PRIMARY KEY (id)) FOR VALUES IN (1);
+CREATE TABLE m (id integer, ts timestamp) PARTITION BY LIST (id);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts := table.Columns[1]; ts.Name != "ts" || !ts.NotNull {
+		t.Errorf("ts: NotNull = %v, want NOT NULL from the partition", ts.NotNull)
+	}
+	if len(table.Constraints) != 1 || table.Constraints[0].Name != "m_1_pk" {
+		t.Errorf("constraints = %+v, want m_1_pk", table.Constraints)
+	}
+
+	// the parent is not in the script
+	table, err = ParsePgSql(`CREATE TABLE m_1 (id integer);
+ALTER TABLE ONLY public.m ATTACH PARTITION public.m_1 FOR VALUES IN (1, 2);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &types.PostgresTable{PartitionOf: "public.m", PartitionBound: &types.PartitionDefinition{Name: "m_1", In: []string{"1", "2"}}}
+	if !reflect.DeepEqual(table.Postgres, want) {
+		t.Errorf("Postgres = %+v, want %+v", table.Postgres, want)
+	}
+}
+
+func TestParsePgInherits(t *testing.T) {
+	table, err := ParsePgSql(`CREATE TABLE c (extra text, name varchar(20) NOT NULL) INHERITS (p1, public.p2);
+CREATE TABLE p1 (id integer, name varchar(10));
+CREATE TABLE public.p2 (id integer, created date);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Postgres == nil || !reflect.DeepEqual(table.Postgres.Inherits, []string{"p1", "public.p2"}) {
+		t.Fatalf("Postgres = %+v, want inherits p1 and public.p2", table.Postgres)
+	}
+	var names []string
+	for _, c := range table.Columns {
+		names = append(names, c.Name)
+	}
+	if want := []string{"id", "name", "created", "extra"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("columns = %q, want %q", names, want)
+	}
+	if name := table.Columns[1]; name.CharLength != 20 || !name.NotNull {
+		t.Errorf("name: CharLength = %d, NotNull = %v, want the definition of c", name.CharLength, name.NotNull)
+	}
+
+	// the parents are not in the script
+	table, err = ParsePgSql(`CREATE TABLE c () INHERITS (other);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Columns) != 0 || table.Postgres == nil || !reflect.DeepEqual(table.Postgres.Inherits, []string{"other"}) {
+		t.Errorf("columns = %d, Postgres = %+v, want no columns inheriting other", len(table.Columns), table.Postgres)
+	}
+}
+
+func TestClonePgColumn(t *testing.T) {
+	c := &types.AntlrColumn{
+		Name:      "a",
+		Identity:  &types.Identity{Seed: 1},
+		Generated: &types.Generated{Expression: "b + 1"},
+		Values:    []string{"x"},
+		Fields:    []*types.AntlrColumn{{Name: "f", Values: []string{"y"}}},
+	}
+	clone := clonePgColumn(c)
+	if !reflect.DeepEqual(clone, c) {
+		t.Fatalf("clone = %+v, want %+v", clone, c)
+	}
+
+	clone.Identity.Seed = 2
+	clone.Generated.Expression = "b"
+	clone.Values[0] = "z"
+	clone.Fields[0].Name = "g"
+	clone.Fields[0].Values[0] = "z"
+	if c.Identity.Seed != 1 || c.Generated.Expression != "b + 1" || c.Values[0] != "x" || c.Fields[0].Name != "f" || c.Fields[0].Values[0] != "y" {
+		t.Errorf("original changed with its clone: %+v", c)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/antlr4-go/antlr/v4"
 	"math"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -19,9 +20,20 @@ type OracleVisitor struct {
 	Table  *types.AntlrTable
 	Column *types.AntlrColumn
 	Err    error
+
+	dialect    types.Dialect              // Oracle, or Dameng for DM8
+	comments   *antlr.CommonTokenStream   // set when inline comments are used
+	virtuals   map[string]string          // expressions of the virtual columns, keyed by column name
+	dmTypes    map[string]damengType      // DM8 types the grammar does not accept, keyed by column name
+	identities map[string]*types.Identity // DM8 IDENTITY(seed, increment) columns, keyed by column name
+}
+
+func ParsePlSql(sql string, opts ...Option) (*types.AntlrTable, error) {
+	return parsePlSqlScript(sql, types.Oracle, opts)
 }
 
-func ParsePlSql(sql string) (table *types.AntlrTable, err error) {
+// parsePlSqlScript parses the script of oracle or of a dialect parsed as oracle, e.g. DM8.
+func parsePlSqlScript(sql string, dialect types.Dialect, opts []Option) (table *types.AntlrTable, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			table = nil
@@ -29,12 +41,12 @@ func ParsePlSql(sql string) (table *types.AntlrTable, err error) {
 		}
 	}()
 
-	sqls := strings.Split(sql, ";")
+	// scripts of SQL Developer end the statements with / lines
+	sqls := splitOracleStatements(sql)
 
 	for _, s := range sqls {
-		s += ";"
-		if len(s) > 12 && strings.ToUpper(s[:12]) == "CREATE TABLE" {
-			table, err = parseOracleTable(s)
+		if strings.HasPrefix(statementHead(s), "CREATE TABLE") {
+			table, err = parseOracleTable(s+";", dialect, newOptions(opts))
 			if err != nil {
 				return nil, err
 			}
@@ -46,9 +58,19 @@ func ParsePlSql(sql string) (table *types.AntlrTable, err error) {
 		return nil, errors.New("not dound create table statment")
 	}
 
+	applyOracleTriggers(table, sqls)
+
 	for _, s := range sqls {
-		s = strings.TrimSpace(s)
-		if len(s) > 17 && strings.ToUpper(s[:17]) == "COMMENT ON COLUMN" {
+		head := statementHead(s)
+		if strings.HasPrefix(head, "CREATE SEQUENCE") {
+			seq, err := parseOracleSequence(s + ";")
+			if err != nil {
+				return nil, err
+			}
+			applySequence(table, seq)
+			continue
+		}
+		if strings.HasPrefix(head, "COMMENT ON COLUMN") {
 			col, err := parseOracleColumnComment(s)
 			if err != nil {
 				return nil, err
@@ -57,13 +79,13 @@ func ParsePlSql(sql string) (table *types.AntlrTable, err error) {
 				continue
 			}
 			for _, c := range table.Columns {
-				if c.Name == col.Name {
+				if identifierEqual(types.Oracle, c.Name, col.Name) {
 					c.Comment = col.Comment
 				}
 			}
 			continue
 		}
-		if len(s) > 16 && strings.ToUpper(s[:16]) == "COMMENT ON TABLE" {
+		if strings.HasPrefix(head, "COMMENT ON TABLE") {
 			t, err := parseOracleTableComment(s)
 			if err != nil {
 				return nil, err
@@ -79,19 +101,22 @@ func (v *OracleVisitor) VisitCreate_table(ctx *parser.Create_tableContext) inter
 		v.Err = errors.New("table name is nil")
 		return nil
 	} else {
-		v.Table.Name = strings.Trim(ctx.Table_name().GetText(), "\"")
+		v.Table.Name, v.Table.Quoted = normalizeIdentifier(types.Oracle, ctx.Table_name().GetText())
 	}
 
 	if ctx.Schema_name() != nil {
-		v.Table.Database = strings.Trim(ctx.Schema_name().GetText(), "\"")
+		v.Table.Schema, _ = normalizeIdentifier(types.Oracle, ctx.Schema_name().GetText())
+		v.Table.Database = v.Table.Schema
 	}
 
 	if ctx.Relational_table() == nil {
 		v.Err = errors.New("relational table is nil")
 		return nil
 	}
+	v.Table.Comment = inlineTableComment(v.comments, ctx, ctx.Relational_table().LEFT_PAREN())
 
-	for _, child := range ctx.Relational_table().AllRelational_property() {
+	rt := ctx.Relational_table()
+	for _, child := range rt.AllRelational_property() {
 		colDef := child.Column_definition()
 		if colDef == nil {
 			continue
@@ -111,38 +136,471 @@ func (v *OracleVisitor) VisitCreate_table(ctx *parser.Create_tableContext) inter
 		v.Err = errors.New("no column found")
 	}
 
+	// the out of line constraints may name columns declared after them
+	for _, child := range rt.AllRelational_property() {
+		if child.Out_of_line_constraint() != nil {
+			v.addOutOfLineConstraint(child.Out_of_line_constraint())
+		}
+	}
+
+	v.setPhysicalProperties(rt.Physical_properties())
+	if tp := rt.Table_properties(); tp != nil {
+		v.setPhysicalProperties(tp.Physical_properties())
+		if tp.Table_partitioning_clauses() != nil {
+			v.setPartitioning(tp.Table_partitioning_clauses())
+		}
+	}
 	return nil
 }
 
+// setPartitioning sets the PARTITION BY clause with its subpartitioning and partition definitions.
+// INTERVAL partitioning is range partitioning with an interval, the keys of REFERENCE partitioning
+// are the foreign key constraint.
+func (v *OracleVisitor) setPartitioning(ctx parser.ITable_partitioning_clausesContext) {
+	partitioning := &types.Partitioning{}
+	switch {
+	case ctx.Range_partitions() != nil:
+		p := ctx.Range_partitions()
+		partitioning.Strategy = types.RangePartition
+		partitioning.Keys = oracleColumnNames(p.AllColumn_name())
+		if p.INTERVAL() != nil {
+			partitioning.Interval = getOriginalText(p.Expression())
+		}
+		partitioning.Definitions = v.getPartitionDefinitions(p)
+	case ctx.List_partitions() != nil:
+		p := ctx.List_partitions()
+		partitioning.Strategy = types.ListPartition
+		partitioning.Keys = oracleColumnNames([]parser.IColumn_nameContext{p.Column_name()})
+		partitioning.Definitions = v.getPartitionDefinitions(p)
+	case ctx.Hash_partitions() != nil:
+		p := ctx.Hash_partitions()
+		partitioning.Strategy = types.HashPartition
+		partitioning.Keys = oracleColumnNames(p.AllColumn_name())
+		v.setHashPartitions(partitioning, p.Individual_hash_partitions(), p.Hash_partitions_by_quantity())
+	case ctx.Composite_range_partitions() != nil:
+		p := ctx.Composite_range_partitions()
+		partitioning.Strategy = types.RangePartition
+		partitioning.Keys = oracleColumnNames(p.AllColumn_name())
+		if p.INTERVAL() != nil {
+			partitioning.Interval = getOriginalText(p.Expression())
+		}
+		partitioning.Subpartitioning = v.getSubpartitioning(p.Subpartition_by_range(), p.Subpartition_by_list(), p.Subpartition_by_hash())
+		partitioning.Definitions = v.getPartitionDefinitions(p)
+	case ctx.Composite_list_partitions() != nil:
+		p := ctx.Composite_list_partitions()
+		partitioning.Strategy = types.ListPartition
+		partitioning.Keys = oracleColumnNames([]parser.IColumn_nameContext{p.Column_name()})
+		partitioning.Subpartitioning = v.getSubpartitioning(p.Subpartition_by_range(), p.Subpartition_by_list(), p.Subpartition_by_hash())
+		partitioning.Definitions = v.getPartitionDefinitions(p)
+	case ctx.Composite_hash_partitions() != nil:
+		p := ctx.Composite_hash_partitions()
+		partitioning.Strategy = types.HashPartition
+		partitioning.Keys = oracleColumnNames(p.AllColumn_name())
+		partitioning.Subpartitioning = v.getSubpartitioning(p.Subpartition_by_range(), p.Subpartition_by_list(), p.Subpartition_by_hash())
+		v.setHashPartitions(partitioning, p.Individual_hash_partitions(), p.Hash_partitions_by_quantity())
+	case ctx.Reference_partitioning() != nil:
+		p := ctx.Reference_partitioning()
+		partitioning.Strategy = types.ReferencePartition
+		name, _ := normalizeIdentifier(types.Oracle, p.Regular_id().GetText())
+		partitioning.Keys = []string{name}
+		partitioning.Definitions = v.getPartitionDefinitions(p)
+	case ctx.System_partitioning() != nil:
+		p := ctx.System_partitioning()
+		partitioning.Strategy = types.SystemPartition
+		if p.UNSIGNED_INTEGER() != nil {
+			partitioning.Count, _ = strconv.Atoi(p.UNSIGNED_INTEGER().GetText())
+		}
+		partitioning.Definitions = v.getPartitionDefinitions(p)
+	}
+	v.Table.Partitioning = partitioning
+}
+
+// getSubpartitioning returns the SUBPARTITION BY clause, the subpartitions of its template are
+// the definitions.
+func (v *OracleVisitor) getSubpartitioning(byRange parser.ISubpartition_by_rangeContext, byList parser.ISubpartition_by_listContext,
+	byHash parser.ISubpartition_by_hashContext) *types.Partitioning {
+	var template parser.ISubpartition_templateContext
+	sub := &types.Partitioning{}
+	switch {
+	case byRange != nil:
+		sub.Strategy = types.RangePartition
+		sub.Keys = oracleColumnNames(byRange.AllColumn_name())
+		template = byRange.Subpartition_template()
+	case byList != nil:
+		sub.Strategy = types.ListPartition
+		sub.Keys = oracleColumnNames([]parser.IColumn_nameContext{byList.Column_name()})
+		template = byList.Subpartition_template()
+	case byHash != nil:
+		sub.Strategy = types.HashPartition
+		sub.Keys = oracleColumnNames(byHash.AllColumn_name())
+		if byHash.UNSIGNED_INTEGER() != nil {
+			sub.Count, _ = strconv.Atoi(byHash.UNSIGNED_INTEGER().GetText())
+		}
+		template = byHash.Subpartition_template()
+	default:
+		return nil
+	}
+	if template != nil {
+		sub.Definitions = v.getPartitionDefinitions(template)
+	}
+	return sub
+}
+
+// setHashPartitions sets the hash partitions declared one by one, or their number of PARTITIONS n.
+func (v *OracleVisitor) setHashPartitions(partitioning *types.Partitioning, individual parser.IIndividual_hash_partitionsContext,
+	byQuantity parser.IHash_partitions_by_quantityContext) {
+	if individual != nil {
+		partitioning.Definitions = v.getPartitionDefinitions(individual)
+	}
+	if byQuantity != nil {
+		partitioning.Count, _ = strconv.Atoi(byQuantity.Hash_partition_quantity().GetText())
+	}
+}
+
+// getPartitionDefinitions returns the partitions declared in ctx. A partition starts at its
+// PARTITION or SUBPARTITION keyword, its name, bounds, storage and subpartitions follow it. The
+// partition descriptions of composite partitioning are partitions of their own.
+func (v *OracleVisitor) getPartitionDefinitions(ctx antlr.ParserRuleContext) []*types.PartitionDefinition {
+	var partitions []*types.PartitionDefinition
+	var partition *types.PartitionDefinition
+	children := ctx.GetChildren()
+	for i, child := range children {
+		switch c := child.(type) {
+		case antlr.TerminalNode:
+			t := c.GetSymbol().GetTokenType()
+			if t != parser.PlSqlParserPARTITION && t != parser.PlSqlParserSUBPARTITION {
+				continue
+			}
+			// not PARTITION BY or SUBPARTITION TEMPLATE
+			if i+1 < len(children) {
+				if next, ok := children[i+1].(antlr.TerminalNode); ok {
+					if n := next.GetSymbol().GetTokenType(); n == parser.PlSqlParserBY || n == parser.PlSqlParserTEMPLATE {
+						continue
+					}
+				}
+			}
+			partition = &types.PartitionDefinition{}
+			partitions = append(partitions, partition)
+		case parser.IPartition_nameContext, parser.ISubpartition_nameContext:
+			partition.Name, _ = normalizeIdentifier(types.Oracle, c.(antlr.ParserRuleContext).GetText())
+		case parser.IRange_values_clauseContext:
+			partition.To = oracleLiterals(c.AllLiteral(), "")
+		case parser.IList_values_clauseContext:
+			partition.Default = c.DEFAULT() != nil
+			partition.In = oracleLiterals(c.AllLiteral(), If(len(c.AllTIMESTAMP()) > 0, "TIMESTAMP ", ""))
+		case parser.ITable_partition_descriptionContext:
+			partition.Tablespace = oraclePartitionTablespace(c)
+		case parser.IPartitioning_storage_clauseContext:
+			partition.Tablespace = oracleStorageTablespace(c)
+		case parser.IRange_partition_descContext, parser.IList_partition_descContext, parser.IReference_partition_descContext:
+			partitions = append(partitions, v.getPartitionDefinitions(c.(antlr.ParserRuleContext))...)
+		case parser.IRange_subpartition_descContext, parser.IList_subpartition_descContext, parser.IIndividual_hash_subpartsContext:
+			subpartitions := v.getPartitionDefinitions(c.(antlr.ParserRuleContext))
+			if partition == nil {
+				// SUBPARTITION TEMPLATE
+				partitions = append(partitions, subpartitions...)
+			} else {
+				partition.Subpartitions = append(partition.Subpartitions, subpartitions...)
+			}
+		}
+	}
+	return partitions
+}
+
+func oracleLiterals(literals []parser.ILiteralContext, prefix string) []string {
+	var values []string
+	for _, literal := range literals {
+		values = append(values, prefix+getOriginalText(literal))
+	}
+	return values
+}
+
+// oraclePartitionTablespace returns the TABLESPACE of the partition, not the one of its overflow segment.
+func oraclePartitionTablespace(ctx parser.ITable_partition_descriptionContext) string {
+	attrs := ctx.AllSegment_attributes_clause()
+	if len(attrs) == 0 || attrs[0].GetTablespace_name() == nil {
+		return ""
+	}
+	if ctx.OVERFLOW() != nil && attrs[0].GetStart().GetTokenIndex() > ctx.OVERFLOW().GetSymbol().GetTokenIndex() {
+		return ""
+	}
+	name, _ := normalizeIdentifier(types.Oracle, attrs[0].GetTablespace_name().GetText())
+	return name
+}
+
+// oracleStorageTablespace returns the TABLESPACE of the (sub)partition, not OVERFLOW TABLESPACE.
+func oracleStorageTablespace(ctx parser.IPartitioning_storage_clauseContext) string {
+	children := ctx.GetChildren()
+	for i, child := range children {
+		tablespace, ok := child.(parser.ITablespaceContext)
+		if !ok {
+			continue
+		}
+		if i >= 2 {
+			if t, ok := children[i-2].(antlr.TerminalNode); ok && t.GetSymbol().GetTokenType() == parser.PlSqlParserOVERFLOW {
+				continue
+			}
+		}
+		name, _ := normalizeIdentifier(types.Oracle, tablespace.GetText())
+		return name
+	}
+	return ""
+}
+
+// setPhysicalProperties reads SEGMENT CREATION and TABLESPACE, PCTFREE, STORAGE (...) and the
+// other segment attributes are accepted and ignored.
+func (v *OracleVisitor) setPhysicalProperties(ctx parser.IPhysical_propertiesContext) {
+	if ctx == nil {
+		return
+	}
+	if segment := ctx.Deferred_segment_creation(); segment != nil {
+		v.oracleTable().SegmentCreation = If(segment.DEFERRED() != nil, "DEFERRED", "IMMEDIATE")
+	}
+	for _, attrs := range ctx.AllSegment_attributes_clause() {
+		if attrs.GetTablespace_name() != nil {
+			v.Table.Tablespace, _ = normalizeIdentifier(types.Oracle, attrs.GetTablespace_name().GetText())
+		}
+	}
+}
+
+// oracleTable returns the oracle specific options of the table, creating them if needed.
+func (v *OracleVisitor) oracleTable() *types.OracleTable {
+	if v.Table.Oracle == nil {
+		v.Table.Oracle = &types.OracleTable{}
+	}
+	return v.Table.Oracle
+}
+
+// addOutOfLineConstraint adds a PRIMARY KEY, UNIQUE, FOREIGN KEY or CHECK table constraint,
+// the columns of an enabled primary key are not null.
+func (v *OracleVisitor) addOutOfLineConstraint(ctx parser.IOut_of_line_constraintContext) {
+	constraint := &types.Constraint{}
+	if ctx.Constraint_name() != nil {
+		constraint.Name, _ = normalizeIdentifier(types.Oracle, ctx.Constraint_name().GetText())
+	}
+	setConstraintState(constraint, ctx.Constraint_state())
+	switch {
+	case ctx.PRIMARY() != nil || ctx.UNIQUE() != nil:
+		constraint.Type = If(ctx.PRIMARY() != nil, types.PrimaryKey, types.Unique)
+		constraint.Columns = oracleColumnNames(ctx.AllColumn_name())
+	case ctx.Foreign_key_clause() != nil:
+		fk := ctx.Foreign_key_clause()
+		constraint.Type = types.ForeignKey
+		constraint.Columns = oracleColumnNames(fk.Paren_column_list().Column_list().AllColumn_name())
+		constraint.References = getOracleReference(fk.References_clause())
+		if fk.On_delete_clause() != nil {
+			constraint.References.OnDelete = If(fk.On_delete_clause().CASCADE() != nil, "CASCADE", "SET NULL")
+		}
+	case ctx.Condition() != nil:
+		constraint.Type = types.Check
+		constraint.Check = getOriginalText(ctx.Condition())
+	default:
+		return
+	}
+
+	if constraint.Type == types.PrimaryKey && !constraint.Disabled {
+		for _, name := range constraint.Columns {
+			for _, c := range v.Table.Columns {
+				if identifierEqual(types.Oracle, c.Name, name) {
+					c.NotNull = true
+				}
+			}
+		}
+	}
+	v.Table.Constraints = append(v.Table.Constraints, constraint)
+}
+
+// setInlineConstraint applies NULL / NOT NULL, the keys and checks are recorded as table
+// constraints of the column. A disabled NOT NULL or primary key does not make the column not null.
+func (v *OracleVisitor) setInlineConstraint(col *types.AntlrColumn, ctx parser.IInline_constraintContext) {
+	constraint := &types.Constraint{Columns: []string{col.Name}}
+	if ctx.Constraint_name() != nil {
+		constraint.Name, _ = normalizeIdentifier(types.Oracle, ctx.Constraint_name().GetText())
+	}
+	setConstraintState(constraint, ctx.Constraint_state())
+	switch {
+	case ctx.NULL_() != nil:
+		col.NotNull = ctx.NOT() != nil && !constraint.Disabled
+		return
+	case ctx.PRIMARY() != nil:
+		constraint.Type = types.PrimaryKey
+		col.NotNull = col.NotNull || !constraint.Disabled
+	case ctx.UNIQUE() != nil:
+		constraint.Type = types.Unique
+	case ctx.References_clause() != nil:
+		constraint.Type = types.ForeignKey
+		constraint.References = getOracleReference(ctx.References_clause())
+	case ctx.Check_constraint() != nil:
+		constraint.Type = types.Check
+		constraint.Check = getOriginalText(ctx.Check_constraint().Condition())
+		if ctx.Check_constraint().DISABLE() != nil {
+			// not validated unless followed by VALIDATE
+			state := ctx.Constraint_state()
+			constraint.Disabled = true
+			constraint.NoValidate = state == nil || len(state.AllVALIDATE()) == 0
+		}
+	default:
+		return
+	}
+	v.Table.Constraints = append(v.Table.Constraints, constraint)
+}
+
 func (v *OracleVisitor) VisitColumn_definition(ctx *parser.Column_definitionContext) interface{} {
 	if ctx.Column_name() == nil {
 		v.Err = errors.New("column name is nil")
 		return nil
 	}
-	if ctx.Datatype() == nil {
+	name, quoted := normalizeIdentifier(types.Oracle, ctx.Column_name().GetText())
+	expression, virtual := v.virtuals[name]
+	dmType, extracted := v.dmTypes[name]
+	if ctx.Datatype() == nil && ctx.Regular_id() == nil && !virtual && !extracted {
 		v.Err = errors.New("data type is nil")
 		return nil
 	}
-	if ctx.Datatype().INTERVAL() != nil {
+	if ctx.Datatype() != nil && ctx.Datatype().INTERVAL() != nil {
 		v.Err = errors.New("'INTERVAL ???' type is not supported")
 		return nil
 	}
-	if ctx.Datatype().TIME() != nil {
-		v.Err = errors.New("'TIMESTAMP WITH ???' type is not supported")
-		return nil
-	}
 
-	ret, err := v.parseColumnType(ctx.Datatype().GetText())
+	// the type of a virtual column declared without one is derived from its expression, the
+	// INVISIBLE of "col INVISIBLE AS (...)" is then taken for a type name by the grammar, and
+	// so is the one following a blanked out DM8 type
+	untyped := virtual || extracted
+	column := &types.AntlrColumn{}
+	var err error
+	switch {
+	case extracted:
+		column, err = v.parseDamengType(dmType)
+	case ctx.Datatype() != nil || ctx.Regular_id() != nil && !untyped:
+		column, err = v.parseColumnType(ctx)
+	}
 	if err != nil {
 		v.Err = err
 		return nil
 	}
 
-	return &types.AntlrColumn{
-		Name:         strings.Trim(ctx.Column_name().GetText(), "\""),
-		DataType:     ret.DataType,
-		StringLength: ret.StringLength,
-		Scale:        ret.Scale,
+	column.Name, column.Quoted = name, quoted
+	if virtual {
+		column.Generated = &types.Generated{Expression: expression}
+	}
+	column.Invisible = ctx.INVISIBLE() != nil ||
+		untyped && ctx.Regular_id() != nil && strings.EqualFold(ctx.Regular_id().GetText(), "INVISIBLE")
+	if ctx.COLLATE() != nil && ctx.Column_collation_name() != nil {
+		column.Collation, _ = normalizeIdentifier(types.Oracle, ctx.Column_collation_name().GetText())
+	}
+	v.setIdentity(column, ctx)
+	for _, constraint := range ctx.AllInline_constraint() {
+		v.setInlineConstraint(column, constraint)
+	}
+	column.Comment = inlineComment(v.comments, ctx)
+	return column
+}
+
+// setIdentity handles GENERATED ... AS IDENTITY, DEFAULT <sequence>.NEXTVAL and the DM8 IDENTITY.
+func (v *OracleVisitor) setIdentity(col *types.AntlrColumn, ctx *parser.Column_definitionContext) {
+	if id, ok := v.identities[col.Name]; ok {
+		col.AutoIncrement = true
+		col.Identity = id
+		return
+	}
+	if id := ctx.Identity_clause(); id != nil {
+		col.AutoIncrement = true
+		// without ALWAYS or BY DEFAULT the identity is ALWAYS
+		col.Identity = newIdentity(id.BY() != nil)
+		if id.Identity_options_parentheses() == nil {
+			return
+		}
+		for _, opt := range id.Identity_options_parentheses().AllIdentity_options() {
+			if opt.Numeric() == nil {
+				continue
+			}
+			n, err := strconv.ParseInt(opt.Numeric().GetText(), 10, 64)
+			if err != nil {
+				continue
+			}
+			if opt.START() != nil {
+				col.Identity.Seed = n
+			} else if opt.INCREMENT() != nil {
+				col.Identity.Increment = n
+			}
+		}
+		return
+	}
+	if ctx.DEFAULT() != nil && ctx.Expression() != nil {
+		matches := oracleNextvalRegexp.FindStringSubmatch(ctx.Expression().GetText())
+		if matches == nil {
+			return
+		}
+		col.AutoIncrement = true
+		col.Identity = newIdentity(true)
+		parts, _ := normalizeQualifiedName(types.Oracle, matches[1])
+		col.Identity.Sequence = strings.Join(parts, ".")
+	}
+}
+
+func (v *OracleVisitor) VisitCreate_sequence(ctx *parser.Create_sequenceContext) interface{} {
+	if ctx.Sequence_name() == nil {
+		v.Err = errors.New("sequence name is nil")
+		return nil
+	}
+	parts, _ := normalizeQualifiedName(types.Oracle, ctx.Sequence_name().GetText())
+	v.Column = &types.AntlrColumn{
+		Name:     strings.Join(parts, "."),
+		Identity: newIdentity(true),
+	}
+	for _, start := range ctx.AllSequence_start_clause() {
+		if n, err := strconv.ParseInt(start.UNSIGNED_INTEGER().GetText(), 10, 64); err == nil {
+			v.Column.Identity.Seed = n
+		}
+	}
+	for _, spec := range ctx.AllSequence_spec() {
+		if spec.INCREMENT() == nil || spec.UNSIGNED_INTEGER() == nil {
+			continue
+		}
+		if n, err := strconv.ParseInt(spec.UNSIGNED_INTEGER().GetText(), 10, 64); err == nil {
+			v.Column.Identity.Increment = n
+		}
+	}
+	return nil
+}
+
+// applyOracleTriggers detects the pre-12c auto-increment pattern, a BEFORE INSERT trigger
+// assigning <sequence>.NEXTVAL to :NEW.<column>, each trigger is a statement of the script.
+func applyOracleTriggers(table *types.AntlrTable, sqls []string) {
+	for _, sql := range sqls {
+		m := oracleTriggerRegexp.FindStringSubmatch(sql)
+		if m == nil {
+			continue
+		}
+		tableName, _ := normalizeQualifiedName(types.Oracle, m[1])
+		if !identifierEqual(types.Oracle, tableName[len(tableName)-1], table.Name) {
+			continue
+		}
+		// the schema is compared when both have one
+		if len(tableName) > 1 && table.Schema != "" && !identifierEqual(types.Oracle, tableName[len(tableName)-2], table.Schema) {
+			continue
+		}
+		seqName, colName := m[2], m[3]
+		if colName == "" {
+			seqName, colName = m[5], m[4]
+		}
+		seq, _ := normalizeQualifiedName(types.Oracle, seqName)
+		colName, _ = normalizeIdentifier(types.Oracle, colName)
+		for _, c := range table.Columns {
+			if !identifierEqual(types.Oracle, c.Name, colName) {
+				continue
+			}
+			c.AutoIncrement = true
+			// "IF :NEW.ID IS NULL THEN" keeps explicitly inserted values
+			explicitInsert := false
+			for _, n := range oracleNewIsNullRegexp.FindAllStringSubmatch(m[0], -1) {
+				if name, _ := normalizeIdentifier(types.Oracle, n[1]); identifierEqual(types.Oracle, name, colName) {
+					explicitInsert = true
+				}
+			}
+			c.Identity = newIdentity(explicitInsert)
+			c.Identity.Sequence = strings.Join(seq, ".")
+		}
 	}
 }
 
@@ -150,10 +608,10 @@ func (v *OracleVisitor) VisitComment_on_column(ctx *parser.Comment_on_columnCont
 	if ctx.Column_name() == nil || ctx.Quoted_string() == nil {
 		return nil
 	}
-	arr := strings.Split(ctx.Column_name().GetText(), ".")
+	arr, _ := normalizeQualifiedName(types.Oracle, ctx.Column_name().GetText())
 	v.Column = &types.AntlrColumn{
-		Name:    strings.Trim(arr[len(arr)-1], "\""),
-		Comment: strings.Trim(ctx.Quoted_string().GetText(), "'"),
+		Name:    arr[len(arr)-1],
+		Comment: decodeString(types.Oracle, ctx.Quoted_string().GetText()),
 	}
 	return nil
 }
@@ -162,13 +620,13 @@ func (v *OracleVisitor) VisitComment_on_table(ctx *parser.Comment_on_tableContex
 	if ctx.Quoted_string() == nil {
 		return nil
 	}
-	v.Table.Comment = strings.Trim(ctx.Quoted_string().GetText(), "'")
+	v.Table.Comment = decodeString(types.Oracle, ctx.Quoted_string().GetText())
 	return nil
 }
 
 // parseColumnType parses the column type definition and returns an AntlrColumn.
-func (v *OracleVisitor) parseColumnType(typeStr string) (*types.AntlrColumn, error) {
-	originalType, length, scale, err := v.parseTypeString(typeStr)
+func (v *OracleVisitor) parseColumnType(ctx parser.IColumn_definitionContext) (*types.AntlrColumn, error) {
+	originalType, length, scale, err := v.extractColumnTypeInfo(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -179,36 +637,60 @@ func (v *OracleVisitor) parseColumnType(typeStr string) (*types.AntlrColumn, err
 	}
 
 	v.setColumnAttributes(column, originalType, length, scale)
+	dt := ctx.Datatype()
+	charUnit := dt != nil && dt.Precision_part() != nil && dt.Precision_part().CHAR() != nil
+	v.setStringLength(column, originalType, length, charUnit)
 	return column, nil
 }
 
-// parseTypeString parses the type string and extracts the type, length, and scale.
-func (v *OracleVisitor) parseTypeString(typeStr string) (string, int, int, error) {
-	re := regexp.MustCompile(`(?i)^(\w+)(?:\((\d+)(?:,(\d+))?\))?$`)
-	matches := re.FindStringSubmatch(typeStr)
-	if matches == nil {
-		return "", 0, 0, fmt.Errorf("invalid type format: %s", typeStr)
+// extractColumnTypeInfo returns the upper case type name, e.g. LONG RAW, with its precision and
+// scale. NUMBER(*, s) has the precision 38, NUMBER(*) is a NUMBER without precision.
+func (v *OracleVisitor) extractColumnTypeInfo(ctx parser.IColumn_definitionContext) (originalType string, length int, scale int, err error) {
+	dt := ctx.Datatype()
+	if dt == nil {
+		// object types, e.g. XMLTYPE
+		return strings.ToUpper(ctx.Regular_id().GetText()), 0, 0, nil
+	}
+	originalType = strings.ToUpper(strings.Join(strings.Fields(getOriginalText(dt.Native_datatype_element())), " "))
+	if dt.TIME() != nil {
+		originalType += If(dt.LOCAL() != nil, " WITH LOCAL TIME ZONE", " WITH TIME ZONE")
 	}
 
-	originalType := strings.ToUpper(matches[1])
-	length, scale := 0, 0
-	if len(matches) >= 3 && matches[2] != "" {
-		if _, err := fmt.Sscanf(matches[2], "%d", &length); err != nil {
-			return "", 0, 0, fmt.Errorf("invalid type length: %s", matches[2])
-		}
+	pp := dt.Precision_part()
+	if pp == nil {
+		return originalType, 0, 0, nil
 	}
-	if len(matches) >= 4 && matches[3] != "" {
-		if _, err := fmt.Sscanf(matches[3], "%d", &scale); err != nil {
-			return "", 0, 0, fmt.Errorf("invalid type scale: %s", matches[3])
+	numbers := pp.AllNumeric()
+	if pp.ASTERISK() != nil {
+		if len(numbers) == 0 && pp.Numeric_negative() == nil {
+			return originalType, 0, 0, nil
+		}
+		length = 38
+	} else {
+		if length, err = strconv.Atoi(numbers[0].GetText()); err != nil {
+			return "", 0, 0, fmt.Errorf("invalid type length: %s", numbers[0].GetText())
 		}
+		numbers = numbers[1:]
 	}
 
+	text := ""
+	if len(numbers) > 0 {
+		text = numbers[0].GetText()
+	} else if pp.Numeric_negative() != nil {
+		text = pp.Numeric_negative().GetText()
+	}
+	if text != "" {
+		if scale, err = strconv.Atoi(text); err != nil {
+			return "", 0, 0, fmt.Errorf("invalid type scale: %s", text)
+		}
+	}
 	return originalType, length, scale, nil
 }
 
 // mapColumnType maps the original type to a simplified type and initializes the column.
 func (v *OracleVisitor) mapColumnType(originalType string) (*types.AntlrColumn, error) {
-	simplifiedType, exists := types.PLSqlTypeMap[originalType]
+	typeMap := If(v.dialect == types.Dameng, types.DamengTypeMap, types.PLSqlTypeMap)
+	simplifiedType, exists := typeMap[originalType]
 	if !exists {
 		return nil, fmt.Errorf("unsupported data type: %s", originalType)
 	}
@@ -217,34 +699,284 @@ func (v *OracleVisitor) mapColumnType(originalType string) (*types.AntlrColumn,
 }
 
 // setColumnAttributes sets the column attributes based on the original type, length, and scale.
+// INT, INTEGER and SMALLINT are NUMBER(38), the DM8 ones are 32 and 16 bits. NUMBER(p) holds
+// integers, and so does NUMBER(p, -s) rounded to s digits left of the decimal point, a NUMBER
+// without precision is a decimal. FLOAT(b) is a decimal of b binary digits.
 func (v *OracleVisitor) setColumnAttributes(column *types.AntlrColumn, originalType string, length, scale int) {
 	switch originalType {
-	case "BINARY_INTEGER", "PLS_INTEGER", "NATURAL", "NATURALN", "POSITIVE",
-		"POSITIVEN", "INT", "INTEGER", "SMALLINT":
+	case "BINARY_INTEGER", "PLS_INTEGER", "SIMPLE_INTEGER", "NATURAL", "NATURALN", "POSITIVE", "POSITIVEN":
 		column.MaxInteger = math.MaxInt32
+	case "INT", "INTEGER", "SMALLINT":
+		switch {
+		case v.dialect != types.Dameng:
+			column.MaxInteger = getMaxInt64(38)
+		case originalType == "SMALLINT":
+			column.MaxInteger = math.MaxInt16
+		default:
+			column.MaxInteger = math.MaxInt32
+		}
+	case "TINYINT", "BYTE":
+		column.MaxInteger = math.MaxInt8
+	case "BIGINT":
+		column.MaxInteger = math.MaxInt64
 	case "SIGNTYPE":
 		column.MaxInteger = 1
 		column.MinInteger = -1
-	case "BINARY_FLOAT", "REAL":
-		if scale == 0 {
-			column.DataType = "integer"
-			column.MaxInteger = getMaxInt32(length)
-		} else {
-			column.MaxFloat = getMaxFloat32(length)
-			column.Scale = scale
-		}
-	case "BINARY_DOUBLE", "FLOAT", "DOUBLE", "DOUBLE PRECISION", "DEC",
-		"NUMBER", "NUMERIC", "DECIMAL":
-		if scale == 0 {
-			column.DataType = "integer"
-			column.MaxInteger = getMaxInt64(length)
+	case "NUMBER", "NUMERIC", "DECIMAL", "DEC":
+		if length > 0 && scale <= 0 {
+			column.DataType = types.Integer
+			column.MaxInteger = getMaxInt64(length - scale)
 		} else {
 			column.MaxFloat = getMaxFloat64(length)
-			column.Scale = scale
+			column.Scale = If(scale > 0, scale, 2)
 		}
-	case "CHAR", "NCHAR", "VARCAHR", "VARCHAR2", "NVARCHAR2", "CHARACTER", "STRING":
+	case "FLOAT", "REAL", "DOUBLE", "DOUBLE PRECISION":
+		// REAL is FLOAT(63), DOUBLE PRECISION is FLOAT(126)
+		bits := If(length > 0, length, If(originalType == "REAL", 63, 126))
+		column.MaxFloat = getMaxFloat64(int(math.Ceil(float64(bits) * math.Log10(2))))
+		column.Scale = 2
+	case "BINARY_FLOAT":
+		column.MaxFloat = math.MaxFloat32
+		column.Scale = 2
+	case "BINARY_DOUBLE":
+		column.MaxFloat = math.MaxFloat64
+		column.Scale = 2
+	case "CHAR", "NCHAR", "VARCHAR", "VARCHAR2", "NVARCHAR2", "CHARACTER", "STRING":
+		column.StringLength = If(length > 0 && length < 50, length, 50)
+	case "RAW", "BINARY", "VARBINARY":
 		column.StringLength = If(length > 0 && length < 50, length, 50)
+		column.ByteLength = If(length > 0, length, 1)
+		column.LengthUnit = types.Bytes
+	case "ROWID", "UROWID":
+		// the base64 text of an extended rowid, UROWID(n) is at most n bytes
+		column.CharLength = If(originalType == "UROWID" && length > 0, length, 18)
+		column.StringLength = If(column.CharLength < 50, column.CharLength, 50)
+	case "CLOB", "NCLOB", "LONG", "XMLTYPE", "BLOB", "BFILE", "LONG RAW", "TEXT", "LONGVARCHAR", "IMAGE", "LONGVARBINARY":
+		column.StringLength = 50
+	}
+}
+
+// setStringLength sets the length semantics of a string column. CHAR / VARCHAR2 lengths
+// are in bytes unless declared as (n CHAR), NCHAR / NVARCHAR2 lengths are in characters
+// of the national charset.
+func (v *OracleVisitor) setStringLength(column *types.AntlrColumn, originalType string, length int, charUnit bool) {
+	maxBytes, ok := oracleMaxBytes[originalType]
+	if !ok {
+		return
+	}
+	length = If(length > 0, length, 1)
+	charset := "" // the database charset is not part of the ddl, assume AL32UTF8
+	switch {
+	case originalType == "NCHAR" || originalType == "NVARCHAR2":
+		charset = "al16utf16"
+		column.Charset = charset
+		column.CharLength = length
+	case charUnit:
+		column.CharLength = length
+	default:
+		column.LengthUnit = types.Bytes
+		column.ByteLength = length
+	}
+	resolveStringLength(column, charset, maxBytes)
+}
+
+// setConstraintState applies ENABLE / DISABLE and VALIDATE / NOVALIDATE, a disabled constraint
+// is not validated unless declared DISABLE VALIDATE. USING INDEX is accepted and ignored.
+func setConstraintState(constraint *types.Constraint, ctx parser.IConstraint_stateContext) {
+	if ctx == nil {
+		return
+	}
+	constraint.Disabled = len(ctx.AllDISABLE()) > 0
+	constraint.NoValidate = len(ctx.AllNOVALIDATE()) > 0 || constraint.Disabled && len(ctx.AllVALIDATE()) == 0
+}
+
+// getOracleReference returns the referenced table and columns, oracle has ON DELETE only.
+func getOracleReference(ctx parser.IReferences_clauseContext) *types.Reference {
+	parts, _ := normalizeQualifiedName(types.Oracle, ctx.Tableview_name().GetText())
+	reference := &types.Reference{Table: strings.Join(parts, ".")}
+	if ctx.Paren_column_list() != nil {
+		reference.Columns = oracleColumnNames(ctx.Paren_column_list().Column_list().AllColumn_name())
+	}
+	if ctx.DELETE() != nil {
+		reference.OnDelete = If(ctx.CASCADE() != nil, "CASCADE", "SET NULL")
+	}
+	return reference
+}
+
+func oracleColumnNames(columns []parser.IColumn_nameContext) []string {
+	var names []string
+	for _, column := range columns {
+		name, _ := normalizeIdentifier(types.Oracle, column.GetText())
+		names = append(names, name)
+	}
+	return names
+}
+
+// extractOracleLobs reads the LOB (...) STORE AS clauses of the CREATE TABLE statement. The
+// grammar does not accept the quoted column names written by DBMS_METADATA.GET_DDL in them, so
+// they are blanked out of the returned statement, keeping the offsets of the rest. An unquoted
+// segment name is only recognized when its storage parameters follow.
+func extractOracleLobs(sql string) (string, []*types.OracleLob) {
+	b := []byte(sql)
+	masked := maskOracleText(sql)
+	var lobs []*types.OracleLob
+	for _, m := range oracleLobRegexp.FindAllStringSubmatchIndex(masked, -1) {
+		lob := &types.OracleLob{}
+		for start := m[2]; start <= m[3]; {
+			end := strings.IndexByte(masked[start:m[3]], ',')
+			end = If(end < 0, m[3], start+end)
+			if name, _, ok := oracleColumnName(sql, start, end); ok {
+				lob.Columns = append(lob.Columns, name)
+			}
+			start = end + 1
+		}
+		lob.SecureFile = m[4] >= 0 && strings.EqualFold(masked[m[4]:m[5]], "SECUREFILE")
+
+		end, open := m[1], skipOracleSpace(sql, m[1], len(sql))
+		if name, nameEnd, ok := oracleColumnName(sql, m[1], len(sql)); ok {
+			next := skipOracleSpace(sql, nameEnd, len(sql))
+			if sql[nameEnd-1] == '"' || (next < len(sql) && sql[next] == '(') {
+				lob.Segment, end, open = name, nameEnd, next
+			}
+		}
+
+		// the storage parameters in parentheses, e.g. (TABLESPACE "USERS" ENABLE STORAGE IN ROW ...)
+		if open < len(sql) && sql[open] == '(' {
+			end = skipParentheses(masked, open)
+			if t := oracleTablespaceRegexp.FindStringIndex(masked[open:end]); t != nil {
+				lob.Tablespace, _, _ = oracleColumnName(sql, open+t[1], end)
+			}
+		}
+		blankRange(b, m[0], end)
+		lobs = append(lobs, lob)
+	}
+	return string(b), lobs
+}
+
+// extractOracleVirtualColumns reads the [GENERATED ALWAYS] AS (expression) [VIRTUAL] clauses of
+// the column definitions, which the grammar does not accept. They are blanked out of the returned
+// statement, keeping the offsets of the rest, and the expressions are keyed by column name.
+func extractOracleVirtualColumns(sql string) (string, map[string]string) {
+	b := []byte(sql)
+	masked := maskOracleText(sql)
+	virtuals := make(map[string]string)
+	forEachRelationalProperty(masked, func(start, end int) {
+		name, nameEnd, ok := oracleColumnName(sql, start, end)
+		if !ok {
+			return
+		}
+		m := indexTopLevel(oracleVirtualColumnRegexp, masked, nameEnd, end)
+		if m == nil {
+			return
+		}
+		paren := m[1] - 1
+		to := skipParentheses(masked, paren)
+		virtuals[name] = strings.TrimSpace(sql[paren+1 : to-1])
+		if keyword := oracleVirtualRegexp.FindStringIndex(masked[to:end]); keyword != nil {
+			to += keyword[1]
+		}
+		blankRange(b, m[0], to)
+	})
+	return string(b), virtuals
+}
+
+// forEachRelationalProperty calls fn with the bounds of each column definition and out of line
+// constraint of the CREATE TABLE statement, they are separated by the commas outside parentheses.
+// masked is the statement with its strings and comments blanked out, see maskOracleText.
+func forEachRelationalProperty(masked string, fn func(start, end int)) {
+	open := strings.IndexByte(masked, '(')
+	if open < 0 {
+		return
+	}
+	end := skipParentheses(masked, open) - 1
+	for start, i := open+1, open+1; i <= end; {
+		switch {
+		case masked[i] == '(':
+			i = skipParentheses(masked, i)
+		case masked[i] == ',' || i == end:
+			fn(start, i)
+			i++
+			start = i
+		default:
+			i++
+		}
+	}
+}
+
+// oracleColumnName returns the normalized name leading the column definition sql[start:end],
+// after the white spaces and comments, and the offset after it.
+func oracleColumnName(sql string, start, end int) (string, int, bool) {
+	i := skipOracleSpace(sql, start, end)
+	m := oracleColumnNameRegexp.FindStringSubmatchIndex(sql[i:end])
+	if m == nil {
+		return "", 0, false
+	}
+	name, _ := normalizeIdentifier(types.Oracle, sql[i+m[2]:i+m[3]])
+	return name, i + m[1], true
+}
+
+// skipOracleSpace returns the offset of the first character of sql[i:end] which is neither a white
+// space nor in a comment, or end.
+func skipOracleSpace(sql string, i, end int) int {
+	for i < end {
+		switch {
+		case strings.IndexByte(" \t\r\n", sql[i]) >= 0:
+			i++
+		case strings.HasPrefix(sql[i:end], "--"):
+			i = skipLine(sql, i)
+		case strings.HasPrefix(sql[i:end], "/*"):
+			i = skipMySQLBlockComment(sql, i)
+		default:
+			return i
+		}
+	}
+	return end
+}
+
+// indexTopLevel returns the submatch offsets of the first match of re in s[from:to] which is
+// not inside parentheses, or nil. s is masked so that the parentheses can be counted.
+func indexTopLevel(re *regexp.Regexp, s string, from, to int) []int {
+	for _, m := range re.FindAllStringSubmatchIndex(s[from:to], -1) {
+		before := s[from : from+m[0]]
+		if strings.Count(before, "(") != strings.Count(before, ")") {
+			continue
+		}
+		for k := range m {
+			if m[k] >= 0 {
+				m[k] += from
+			}
+		}
+		return m
+	}
+	return nil
+}
+
+// skipParentheses returns the index after the parentheses opened at i, the parentheses in
+// strings, quoted identifiers and comments are skipped.
+func skipParentheses(s string, i int) int {
+	depth := 0
+	for j := i; j < len(s); {
+		switch {
+		case s[j] == '\'' || s[j] == '"':
+			j = skipQuoted(s, j, s[j], false)
+			continue
+		case strings.HasPrefix(s[j:], "--"):
+			j = skipLine(s, j)
+			continue
+		case strings.HasPrefix(s[j:], "/*"):
+			j = skipMySQLBlockComment(s, j)
+			continue
+		case s[j] == '(':
+			depth++
+		case s[j] == ')':
+			depth--
+			if depth == 0 {
+				return j + 1
+			}
+		}
+		j++
 	}
+	return len(s)
 }
 
 func parseOracleColumnComment(sql string) (*types.AntlrColumn, error) {
@@ -262,6 +994,23 @@ func parseOracleColumnComment(sql string) (*types.AntlrColumn, error) {
 	return visitor.Column, visitor.Err
 }
 
+// parseOracleSequence parses a CREATE SEQUENCE statement, the returned column carries
+// the sequence name and its identity settings.
+func parseOracleSequence(sql string) (*types.AntlrColumn, error) {
+	lexer := parser.NewPlSqlLexer(antlr.NewInputStream(sql))
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+
+	p := parser.NewPlSqlParser(stream)
+
+	tree := p.Create_sequence()
+	visitor := &OracleVisitor{
+		BasePlSqlParserVisitor: &parser.BasePlSqlParserVisitor{},
+		Column:                 &types.AntlrColumn{},
+	}
+	tree.Accept(visitor)
+	return visitor.Column, visitor.Err
+}
+
 func parseOracleTableComment(sql string) (string, error) {
 	lexer := parser.NewPlSqlLexer(antlr.NewInputStream(sql))
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
@@ -277,7 +1026,43 @@ func parseOracleTableComment(sql string) (string, error) {
 	return visitor.Table.Comment, visitor.Err
 }
 
-func parseOracleTable(sql string) (*types.AntlrTable, error) {
+// oracleTriggerEvent is a DELETE or UPDATE [OF column, ...] event of a trigger.
+const oracleTriggerEvent = `(?:DELETE|UPDATE(?:\s+OF\s+(?:"[^"]*"|[\w$#]+)(?:\s*,\s*(?:"[^"]*"|[\w$#]+))*)?)`
+
+// oracleMaxBytes is the max bytes of the string datatypes with MAX_STRING_SIZE = STANDARD.
+var oracleMaxBytes = map[string]int{
+	"CHAR":      2000,
+	"CHARACTER": 2000,
+	"NCHAR":     2000,
+	"VARCHAR":   4000,
+	"VARCHAR2":  4000,
+	"NVARCHAR2": 4000,
+	"STRING":    4000,
+}
+
+var (
+	oracleNextvalRegexp = regexp.MustCompile(`(?i)^(.+)\.NEXTVAL$`)
+	oracleTriggerRegexp = regexp.MustCompile(`(?is)CREATE\s+(?:OR\s+REPLACE\s+)?TRIGGER\s+\S+\s+BEFORE\s+` +
+		`(?:` + oracleTriggerEvent + `\s+OR\s+)*INSERT(?:\s+OR\s+` + oracleTriggerEvent + `)*\s+ON\s+(\S+)\s.*?` +
+		`(?:SELECT\s+(\S+?)\.NEXTVAL\s+INTO\s+:NEW\.(\S+?)\s+FROM|:NEW\.(\S+?)\s*:=\s*(\S+?)\.NEXTVAL)`)
+	oracleNewIsNullRegexp = regexp.MustCompile(`(?i):NEW\.("[^"]*"|[\w$#]+)\s+IS\s+NULL`)
+
+	oracleLobRegexp        = regexp.MustCompile(`(?i)\bLOB\s*\(([^)]*)\)\s*STORE\s+AS(?:\s*\b(SECUREFILE|BASICFILE)\b)?`)
+	oracleTablespaceRegexp = regexp.MustCompile(`(?i)\bTABLESPACE\b`)
+
+	oracleColumnNameRegexp    = regexp.MustCompile(`^("[^"]*"|[A-Za-z][\w$#]*)`)
+	oracleVirtualColumnRegexp = regexp.MustCompile(`(?i)\b(?:GENERATED\s+ALWAYS\s+)?AS\s*\(`)
+	oracleVirtualRegexp       = regexp.MustCompile(`(?i)^\s*VIRTUAL\b`)
+)
+
+func parseOracleTable(sql string, dialect types.Dialect, o *options) (*types.AntlrTable, error) {
+	sql, lobs := extractOracleLobs(sql)
+	sql, virtuals := extractOracleVirtualColumns(sql)
+	var dmTypes map[string]damengType
+	var identities map[string]*types.Identity
+	if dialect == types.Dameng {
+		sql, dmTypes, identities = extractDamengColumns(sql)
+	}
 	lexer := parser.NewPlSqlLexer(antlr.NewInputStream(sql))
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 
@@ -288,10 +1073,20 @@ func parseOracleTable(sql string) (*types.AntlrTable, error) {
 	visitor := &OracleVisitor{
 		BasePlSqlParserVisitor: &parser.BasePlSqlParserVisitor{},
 		Table: &types.AntlrTable{
-			Dialect: types.Oracle,
+			Dialect: dialect,
 			Columns: make([]*types.AntlrColumn, 0),
 		},
+		dialect:    dialect,
+		virtuals:   virtuals,
+		dmTypes:    dmTypes,
+		identities: identities,
+	}
+	if o.inlineComments {
+		visitor.comments = stream
 	}
 	tree.Accept(visitor)
+	if lobs != nil {
+		visitor.oracleTable().Lobs = lobs
+	}
 	return visitor.Table, visitor.Err
 }
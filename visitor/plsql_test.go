@@ -0,0 +1,330 @@
+package visitor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/aierdong/createtable-sql-parser/types"
+)
+
+func TestParsePlSqlLobStorage(t *testing.T) {
+	table, err := ParsePlSql(`CREATE TABLE "HR"."DOCS" (
+	"ID" NUMBER(10,0),
+	"BODY" CLOB DEFAULT 'LOB (x) STORE AS y (', -- LOB (z) STORE AS w (
+	"DATA" BLOB
+) SEGMENT CREATION IMMEDIATE
+ LOB ("BODY") STORE AS SECUREFILE "DOCS_BODY" (
+	TABLESPACE "LOBS" ENABLE STORAGE IN ROW CHUNK 8192 /* ) */ NOCACHE LOGGING)
+ LOB ("DATA") STORE AS BASICFILE (
+	TABLESPACE "USERS" ENABLE STORAGE IN ROW)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*types.OracleLob{
+		{Columns: []string{"BODY"}, Segment: "DOCS_BODY", SecureFile: true, Tablespace: "LOBS"},
+		{Columns: []string{"DATA"}, Tablespace: "USERS"},
+	}
+	if table.Oracle == nil || !reflect.DeepEqual(table.Oracle.Lobs, want) {
+		t.Fatalf("Oracle = %+v, want lobs %+v", table.Oracle, want)
+	}
+	if len(table.Columns) != 3 || table.Columns[2].Name != "DATA" {
+		t.Errorf("columns = %d, want ID, BODY and DATA", len(table.Columns))
+	}
+}
+
+func TestParsePlSqlTriggerIdentity(t *testing.T) {
+	table, err := ParsePlSql(`CREATE TABLE hr.t (id NUMBER(10), x NUMBER(10));
+CREATE OR REPLACE TRIGGER other_trg BEFORE INSERT ON other.t FOR EACH ROW
+BEGIN
+  SELECT other_seq.NEXTVAL INTO :NEW.x FROM dual;
+END;
+/
+CREATE OR REPLACE TRIGGER t_trg BEFORE INSERT OR UPDATE OF x, "Y" ON hr.t FOR EACH ROW
+BEGIN
+  IF :NEW.id IS NULL THEN
+    :NEW.id := hr.t_seq.NEXTVAL;
+  END IF;
+END;
+/`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, x := table.Columns[0], table.Columns[1]
+	if !id.AutoIncrement || id.Identity == nil || id.Identity.Sequence != "HR.T_SEQ" || !id.Identity.ExplicitInsert {
+		t.Errorf("ID: AutoIncrement = %v, Identity = %+v, want HR.T_SEQ with explicit inserts", id.AutoIncrement, id.Identity)
+	}
+	if x.AutoIncrement || x.Identity != nil {
+		t.Errorf("X: AutoIncrement = %v, want the trigger on OTHER.T ignored", x.AutoIncrement)
+	}
+}
+
+func TestParsePlSqlStringLength(t *testing.T) {
+	table, err := ParsePlSql(`CREATE TABLE t (
+	a VARCHAR2(10 BYTE),
+	b VARCHAR2(10 CHAR),
+	c VARCHAR2(10),
+	d NVARCHAR2(10),
+	e VARCHAR2(4000 CHAR),
+	f CHAR
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkLengths(t, table, []lengthCase{
+		{"A", types.Bytes, 2, 10, ""},
+		{"B", types.Chars, 10, 40, ""},
+		{"C", types.Bytes, 2, 10, ""},
+		{"D", types.Chars, 10, 20, "al16utf16"},
+		{"E", types.Chars, 1000, 4000, ""},
+		{"F", types.Bytes, 0, 1, ""},
+	})
+}
+
+func TestExtractOracleLobs(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []*types.OracleLob
+	}{
+		{
+			name: "get_ddl",
+			sql: `CREATE TABLE "HR"."DOCS" ("ID" NUMBER, "BODY" CLOB) SEGMENT CREATION IMMEDIATE
+  LOB ("BODY") STORE AS SECUREFILE "DOCS_BODY" (
+  TABLESPACE "LOBS" ENABLE STORAGE IN ROW CHUNK 8192
+  NOCACHE LOGGING NOCOMPRESS KEEP_DUPLICATES
+  STORAGE(INITIAL 106496 NEXT 1048576 MINEXTENTS 1 MAXEXTENTS 2147483645))`,
+			want: []*types.OracleLob{{Columns: []string{"BODY"}, Segment: "DOCS_BODY", SecureFile: true, Tablespace: "LOBS"}},
+		},
+		{
+			name: "several columns",
+			sql:  `CREATE TABLE t (a CLOB, b BLOB) LOB (a, "b") STORE AS BASICFILE (TABLESPACE users)`,
+			want: []*types.OracleLob{{Columns: []string{"A", "b"}, Tablespace: "USERS"}},
+		},
+		{
+			name: "unquoted segment",
+			sql:  `CREATE TABLE t (a CLOB, b CLOB) LOB (a) STORE AS a_seg (CACHE) LOB (b) STORE AS SECUREFILE`,
+			want: []*types.OracleLob{
+				{Columns: []string{"A"}, Segment: "A_SEG"},
+				{Columns: []string{"B"}, SecureFile: true},
+			},
+		},
+		{
+			name: "in strings and comments",
+			sql:  `CREATE TABLE t (a CLOB DEFAULT 'LOB (a) STORE AS s') /* LOB (a) STORE AS s */`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, lobs := extractOracleLobs(tt.sql)
+			if !reflect.DeepEqual(lobs, tt.want) {
+				t.Errorf("lobs = %+v, want %+v", lobs, tt.want)
+			}
+			if len(sql) != len(tt.sql) || oracleLobRegexp.MatchString(maskOracleText(sql)) {
+				t.Errorf("sql = %q, want the lob clauses blanked out", sql)
+			}
+		})
+	}
+}
+
+func TestParsePlSqlGetDDL(t *testing.T) {
+	table, err := ParsePlSql(`
+  CREATE TABLE "HR"."EMP" 
+   (	"ID" NUMBER(10,0) NOT NULL ENABLE, 
+	"NAME" VARCHAR2(50 BYTE) CONSTRAINT "EMP_NAME_NN" NOT NULL DISABLE, 
+	"DEPT_ID" NUMBER(10,0), 
+	"NOTE" CLOB, 
+	 CONSTRAINT "EMP_PK" PRIMARY KEY ("ID")
+  USING INDEX PCTFREE 10 INITRANS 2 MAXTRANS 255 COMPUTE STATISTICS 
+  STORAGE(INITIAL 65536 NEXT 1048576 MINEXTENTS 1 MAXEXTENTS 2147483645
+  PCTINCREASE 0 FREELISTS 1 FREELIST GROUPS 1
+  BUFFER_POOL DEFAULT FLASH_CACHE DEFAULT CELL_FLASH_CACHE DEFAULT)
+  TABLESPACE "USERS"  ENABLE, 
+	 CONSTRAINT "EMP_NAME_UK" UNIQUE ("NAME") DISABLE, 
+	 CONSTRAINT "EMP_DEPT_FK" FOREIGN KEY ("DEPT_ID")
+	  REFERENCES "HR"."DEPT" ("ID") ENABLE NOVALIDATE, 
+	 CONSTRAINT "EMP_ID_CK" CHECK (id > 0) DISABLE VALIDATE
+   ) SEGMENT CREATION IMMEDIATE 
+  PCTFREE 10 PCTUSED 40 INITRANS 1 MAXTRANS 255 
+ NOCOMPRESS LOGGING
+  STORAGE(INITIAL 65536 NEXT 1048576 MINEXTENTS 1 MAXEXTENTS 2147483645
+  PCTINCREASE 0 FREELISTS 1 FREELIST GROUPS 1
+  BUFFER_POOL DEFAULT FLASH_CACHE DEFAULT CELL_FLASH_CACHE DEFAULT)
+  TABLESPACE "USERS" 
+ LOB ("NOTE") STORE AS SECUREFILE (
+  TABLESPACE "LOBS" ENABLE STORAGE IN ROW CHUNK 8192
+  NOCACHE LOGGING  NOCOMPRESS  KEEP_DUPLICATES) 
+/
+
+   COMMENT ON TABLE "HR"."EMP"  IS 'Employees'
+/
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Schema != "HR" || table.Name != "EMP" || table.Tablespace != "USERS" || table.Comment != "Employees" {
+		t.Errorf("table = %s.%s, Tablespace = %q, Comment = %q, want HR.EMP in USERS", table.Schema, table.Name, table.Tablespace, table.Comment)
+	}
+	want := &types.OracleTable{
+		SegmentCreation: "IMMEDIATE",
+		Lobs:            []*types.OracleLob{{Columns: []string{"NOTE"}, SecureFile: true, Tablespace: "LOBS"}},
+	}
+	if !reflect.DeepEqual(table.Oracle, want) {
+		t.Errorf("Oracle = %+v, want %+v", table.Oracle, want)
+	}
+
+	if !table.Columns[0].NotNull || table.Columns[1].NotNull {
+		t.Errorf("NotNull = %v, %v, want ID only, the NOT NULL of NAME is disabled", table.Columns[0].NotNull, table.Columns[1].NotNull)
+	}
+	tests := []struct {
+		name       string
+		typ        types.ConstraintType
+		disabled   bool
+		noValidate bool
+	}{
+		{"EMP_PK", types.PrimaryKey, false, false},
+		{"EMP_NAME_UK", types.Unique, true, true},
+		{"EMP_DEPT_FK", types.ForeignKey, false, true},
+		{"EMP_ID_CK", types.Check, true, false},
+	}
+	if len(table.Constraints) != len(tests) {
+		t.Fatalf("constraints = %d, want %d", len(table.Constraints), len(tests))
+	}
+	for i, tt := range tests {
+		c := table.Constraints[i]
+		if c.Name != tt.name || c.Type != tt.typ || c.Disabled != tt.disabled || c.NoValidate != tt.noValidate {
+			t.Errorf("constraint %s: Type = %s, Disabled = %v, NoValidate = %v, want %s, %v, %v",
+				c.Name, c.Type, c.Disabled, c.NoValidate, tt.typ, tt.disabled, tt.noValidate)
+		}
+	}
+	if ref := table.Constraints[2].References; ref == nil || ref.Table != "HR.DEPT" || !reflect.DeepEqual(ref.Columns, []string{"ID"}) {
+		t.Errorf("EMP_DEPT_FK references %+v, want HR.DEPT (ID)", ref)
+	}
+}
+
+func TestParsePlSqlTypes(t *testing.T) {
+	table, err := ParsePlSql(`CREATE TABLE t (
+	a NUMBER,
+	b NUMBER(*),
+	c NUMBER(*,0),
+	d NUMBER(*,2),
+	e NUMBER(5),
+	f NUMBER(5,-2),
+	g NUMBER(10,2),
+	h FLOAT(10),
+	i FLOAT,
+	j INTEGER,
+	k CLOB,
+	l NCLOB,
+	m BLOB,
+	n BFILE,
+	o LONG,
+	p LONG RAW,
+	q RAW(16),
+	r ROWID,
+	s UROWID(100),
+	u XMLTYPE
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		dataType     types.DbType
+		maxInteger   int64
+		maxFloat     float64
+		scale        int
+		stringLength int
+	}{
+		{"A", types.Numeric, 0, getMaxFloat64(18), 2, 0},
+		{"B", types.Numeric, 0, getMaxFloat64(18), 2, 0},
+		{"C", types.Integer, math.MaxInt64, 0, 0, 0},
+		{"D", types.Numeric, 0, getMaxFloat64(38), 2, 0},
+		{"E", types.Integer, 99999, 0, 0, 0},
+		{"F", types.Integer, 9999999, 0, 0, 0},
+		{"G", types.Numeric, 0, getMaxFloat64(10), 2, 0},
+		{"H", types.Numeric, 0, 9999, 2, 0},
+		{"I", types.Numeric, 0, getMaxFloat64(38), 2, 0},
+		{"J", types.Integer, math.MaxInt64, 0, 0, 0},
+		{"K", types.String, 0, 0, 0, 50},
+		{"L", types.String, 0, 0, 0, 50},
+		{"M", types.Binary, 0, 0, 0, 50},
+		{"N", types.Binary, 0, 0, 0, 50},
+		{"O", types.String, 0, 0, 0, 50},
+		{"P", types.Binary, 0, 0, 0, 50},
+		{"Q", types.Binary, 0, 0, 0, 16},
+		{"R", types.String, 0, 0, 0, 18},
+		{"S", types.String, 0, 0, 0, 50},
+		{"U", types.String, 0, 0, 0, 50},
+	}
+	if len(table.Columns) != len(tests) {
+		t.Fatalf("columns = %d, want %d", len(table.Columns), len(tests))
+	}
+	for i, tt := range tests {
+		c := table.Columns[i]
+		if c.Name != tt.name || c.DataType != tt.dataType || c.MaxInteger != tt.maxInteger ||
+			c.MaxFloat != tt.maxFloat || c.Scale != tt.scale || c.StringLength != tt.stringLength {
+			t.Errorf("%s: DataType = %s, MaxInteger = %d, MaxFloat = %g, Scale = %d, StringLength = %d, want %s, %d, %g, %d, %d",
+				c.Name, c.DataType, c.MaxInteger, c.MaxFloat, c.Scale, c.StringLength,
+				tt.dataType, tt.maxInteger, tt.maxFloat, tt.scale, tt.stringLength)
+		}
+	}
+	if q := table.Columns[16]; q.LengthUnit != types.Bytes || q.ByteLength != 16 {
+		t.Errorf("Q: LengthUnit = %s, ByteLength = %d, want 16 bytes", q.LengthUnit, q.ByteLength)
+	}
+	if s := table.Columns[18]; s.CharLength != 100 {
+		t.Errorf("S: CharLength = %d, want 100", s.CharLength)
+	}
+}
+
+func TestExtractOracleVirtualColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want map[string]string
+	}{
+		{
+			name: "typed and untyped",
+			sql:  `CREATE TABLE t (a NUMBER, b NUMBER GENERATED ALWAYS AS (a * 2) VIRTUAL, "c" AS (ROUND(a, 1)))`,
+			want: map[string]string{"B": "a * 2", "c": "ROUND(a, 1)"},
+		},
+		{
+			name: "in strings and comments",
+			sql:  `CREATE TABLE t (a VARCHAR2(20) DEFAULT 'AS (x)' /* AS (y) */, CONSTRAINT ck CHECK (a <> 'z'))`,
+			want: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, virtuals := extractOracleVirtualColumns(tt.sql)
+			if !reflect.DeepEqual(virtuals, tt.want) {
+				t.Errorf("virtuals = %q, want %q", virtuals, tt.want)
+			}
+			if len(sql) != len(tt.sql) || len(tt.want) > 0 && oracleVirtualColumnRegexp.MatchString(maskOracleText(sql)) {
+				t.Errorf("sql = %q, want the expressions blanked out", sql)
+			}
+		})
+	}
+}
+
+func TestParsePlSqlVirtualColumns(t *testing.T) {
+	table, err := ParsePlSql(`CREATE TABLE t (
+	a NUMBER(10),
+	b NUMBER(12) GENERATED ALWAYS AS (a * 2) VIRTUAL,
+	c AS (a + 1)
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, c := table.Columns[1], table.Columns[2]
+	if b.Generated == nil || b.Generated.Expression != "a * 2" || b.DataType != types.Integer || b.MaxInteger != getMaxInt64(12) {
+		t.Errorf("B: Generated = %+v, DataType = %s, want a * 2 as NUMBER(12)", b.Generated, b.DataType)
+	}
+	if c.Generated == nil || c.Generated.Expression != "a + 1" {
+		t.Errorf("C: Generated = %+v, want a + 1", c.Generated)
+	}
+}
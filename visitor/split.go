@@ -0,0 +1,384 @@
+package visitor
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// splitPgStatements splits a postgres script on the semicolons ending its statements, the
+// semicolons in strings, quoted identifiers, comments and dollar quoted bodies are skipped.
+func splitPgStatements(sql string) []string {
+	var statements []string
+	start := 0
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == ';':
+			statements = append(statements, sql[start:i])
+			i++
+			start = i
+		case c == '\'':
+			// E'...' strings have backslash escapes
+			escapes := i > 0 && (sql[i-1] == 'e' || sql[i-1] == 'E') && (i == 1 || !isIdentChar(sql[i-2]))
+			i = skipQuoted(sql, i, '\'', escapes)
+		case c == '"':
+			i = skipQuoted(sql, i, '"', false)
+		case strings.HasPrefix(sql[i:], "--"):
+			i = skipLine(sql, i)
+		case strings.HasPrefix(sql[i:], "/*"):
+			i = skipBlockComment(sql, i)
+		case c == '$' && (i == 0 || !isIdentChar(sql[i-1])):
+			tag := dollarQuoteRegexp.FindString(sql[i:])
+			if tag == "" {
+				i++
+			} else if end := strings.Index(sql[i+len(tag):], tag); end >= 0 {
+				i += len(tag) + end + len(tag)
+			} else {
+				i = len(sql)
+			}
+		default:
+			i++
+		}
+	}
+	return append(statements, sql[start:])
+}
+
+// skipQuoted returns the index after the string or quoted identifier starting at i,
+// a doubled quote is part of the text.
+func skipQuoted(s string, i int, quote byte, escapes bool) int {
+	for j := i + 1; j < len(s); j++ {
+		switch {
+		case escapes && s[j] == '\\':
+			j++
+		case s[j] == quote && j+1 < len(s) && s[j+1] == quote:
+			j++
+		case s[j] == quote:
+			return j + 1
+		}
+	}
+	return len(s)
+}
+
+// skipLine returns the index after the line comment starting at i.
+func skipLine(s string, i int) int {
+	if end := strings.IndexByte(s[i:], '\n'); end >= 0 {
+		return i + end + 1
+	}
+	return len(s)
+}
+
+// skipBlockComment returns the index after the block comment starting at i, block comments nest.
+func skipBlockComment(s string, i int) int {
+	depth := 0
+	for j := i; j < len(s)-1; j++ {
+		switch s[j : j+2] {
+		case "/*":
+			depth++
+			j++
+		case "*/":
+			depth--
+			j++
+			if depth == 0 {
+				return j + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// statementHead returns the first words of the statement in upper case separated by single
+// spaces, e.g. ALTER TABLE ONLY, for matching the kind of statement.
+func statementHead(sql string) string {
+	head := skipLeadingComments(sql)
+	if len(head) > 64 {
+		head = head[:64]
+	}
+	return strings.ToUpper(strings.Join(strings.Fields(head), " "))
+}
+
+// splitMySQLStatements splits a mysql script on the statement delimiter, which is ; unless
+// changed by a DELIMITER line of the mysql client, e.g. around the triggers of mysqldump.
+func splitMySQLStatements(sql string) []string {
+	var statements []string
+	delimiter := ";"
+	start := 0
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case (i == 0 || sql[i-1] == '\n') && isDelimiterCommand(sql[i:]):
+			statements = append(statements, sql[start:i])
+			end := skipLine(sql, i)
+			if d := strings.TrimSpace(sql[i+len("DELIMITER") : end]); d != "" {
+				delimiter = d
+			}
+			i, start = end, end
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(sql, i, c, c != '`')
+		case isMySQLLineComment(sql[i:]):
+			i = skipLine(sql, i)
+		case strings.HasPrefix(sql[i:], "/*") && !strings.HasPrefix(sql[i:], "/*!"):
+			i = skipMySQLBlockComment(sql, i)
+		case strings.HasPrefix(sql[i:], delimiter):
+			statements = append(statements, sql[start:i])
+			i += len(delimiter)
+			start = i
+		default:
+			i++
+		}
+	}
+	return append(statements, sql[start:])
+}
+
+// resolveMySQLVersionComments resolves the /*! ... */ and /*!NNNNN ... */ conditional comments
+// like a server of the given version: the content of the comment is kept when the version is
+// at least NNNNN, else the whole comment is dropped. Dropped text is blanked so that the
+// positions and lines of the other tokens do not change.
+func resolveMySQLVersionComments(sql string, version int) string {
+	b := []byte(sql)
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(sql, i, c, c != '`')
+		case isMySQLLineComment(sql[i:]):
+			i = skipLine(sql, i)
+		case strings.HasPrefix(sql[i:], "/*!"):
+			j := i + 3
+			for j < len(sql) && sql[j] >= '0' && sql[j] <= '9' {
+				j++
+			}
+			end := versionCommentEnd(sql, j)
+			if end < 0 {
+				return string(b)
+			}
+			if minVersion, err := strconv.Atoi(sql[i+3 : j]); err == nil && minVersion > version {
+				blankRange(b, i, end+2)
+				i = end + 2
+			} else {
+				blankRange(b, i, j)
+				blankRange(b, end, end+2)
+				i = j
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			i = skipMySQLBlockComment(sql, i)
+		default:
+			i++
+		}
+	}
+	return string(b)
+}
+
+// versionCommentEnd returns the index of the */ closing the conditional comment whose content
+// starts at i, or -1. The strings and the comments inside, e.g. nested conditional comments,
+// are skipped.
+func versionCommentEnd(sql string, i int) int {
+	for i < len(sql) {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(sql, i, c, c != '`')
+		case strings.HasPrefix(sql[i:], "*/"):
+			return i
+		case strings.HasPrefix(sql[i:], "/*!"):
+			j := versionCommentEnd(sql, i+3)
+			if j < 0 {
+				return -1
+			}
+			i = j + 2
+		case strings.HasPrefix(sql[i:], "/*"):
+			i = skipMySQLBlockComment(sql, i)
+		default:
+			i++
+		}
+	}
+	return -1
+}
+
+// blankRange replaces b[from:to] with spaces, line breaks are kept so that line numbers stay valid.
+func blankRange(b []byte, from, to int) {
+	for k := from; k < to; k++ {
+		if b[k] != '\n' && b[k] != '\r' {
+			b[k] = ' '
+		}
+	}
+}
+
+// isDelimiterCommand reports whether the line is a DELIMITER command of the mysql client.
+func isDelimiterCommand(line string) bool {
+	return len(line) > 10 && strings.EqualFold(line[:9], "DELIMITER") && (line[9] == ' ' || line[9] == '\t')
+}
+
+// isMySQLLineComment reports whether s starts with a # comment or a -- comment, which must be
+// followed by a white space.
+func isMySQLLineComment(s string) bool {
+	if strings.HasPrefix(s, "#") {
+		return true
+	}
+	return strings.HasPrefix(s, "--") && (len(s) == 2 || strings.ContainsRune(" \t\r\n", rune(s[2])))
+}
+
+// skipMySQLBlockComment returns the index after the block comment starting at i, mysql block
+// comments do not nest.
+func skipMySQLBlockComment(s string, i int) int {
+	if end := strings.Index(s[i+2:], "*/"); end >= 0 {
+		return i + 2 + end + 2
+	}
+	return len(s)
+}
+
+// splitTSqlStatements splits a t-sql script on the GO batch separator lines and on the
+// semicolons, the semicolons in strings, quoted identifiers and comments are skipped.
+func splitTSqlStatements(sql string) []string {
+	var statements []string
+	start := 0
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case (i == 0 || sql[i-1] == '\n') && isGoCommand(sql[i:skipLine(sql, i)]):
+			statements = append(statements, sql[start:i])
+			i = skipLine(sql, i)
+			start = i
+		case c == '\'' || c == '"':
+			i = skipQuoted(sql, i, c, false)
+		case c == '[':
+			i = skipQuoted(sql, i, ']', false)
+		case strings.HasPrefix(sql[i:], "--"):
+			i = skipLine(sql, i)
+		case strings.HasPrefix(sql[i:], "/*"):
+			i = skipBlockComment(sql, i)
+		case c == ';':
+			statements = append(statements, sql[start:i])
+			i++
+			start = i
+		default:
+			i++
+		}
+	}
+	return append(statements, sql[start:])
+}
+
+// isGoCommand reports whether the line is a GO [count] batch separator.
+func isGoCommand(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || len(fields) > 2 || !strings.EqualFold(fields[0], "GO") {
+		return false
+	}
+	if len(fields) == 2 {
+		_, err := strconv.Atoi(fields[1])
+		return err == nil
+	}
+	return true
+}
+
+// maskTSqlText returns the t-sql statement with its strings and comments blanked out, keeping the
+// offsets, so that the options the grammar does not accept can be searched without matching their
+// text. The quoted identifiers are kept.
+func maskTSqlText(sql string) string {
+	b := []byte(sql)
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == '"':
+			i = skipQuoted(sql, i, c, false)
+		case c == '[':
+			i = skipQuoted(sql, i, ']', false)
+		case c == '\'':
+			end := skipQuoted(sql, i, c, false)
+			blankRange(b, i, end)
+			i = end
+		case strings.HasPrefix(sql[i:], "--"):
+			end := skipLine(sql, i)
+			blankRange(b, i, end)
+			i = end
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := skipBlockComment(sql, i)
+			blankRange(b, i, end)
+			i = end
+		default:
+			i++
+		}
+	}
+	return string(b)
+}
+
+// splitOracleStatements splits an oracle script on the semicolons and on the / lines of
+// SQL*Plus, the semicolons in strings, quoted identifiers and comments are skipped. A PL/SQL
+// unit, e.g. a trigger, contains semicolons and only ends at a / line.
+func splitOracleStatements(sql string) []string {
+	var statements []string
+	start := 0
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case (i == 0 || sql[i-1] == '\n') && strings.TrimSpace(sql[i:skipLine(sql, i)]) == "/":
+			statements = append(statements, sql[start:i])
+			i = skipLine(sql, i)
+			start = i
+		case c == '\'' && i > 0 && (sql[i-1] == 'q' || sql[i-1] == 'Q') && (i == 1 || !isIdentChar(sql[i-2])):
+			i = skipOracleQuoted(sql, i)
+		case c == '\'' || c == '"':
+			i = skipQuoted(sql, i, c, false)
+		case strings.HasPrefix(sql[i:], "--"):
+			i = skipLine(sql, i)
+		case strings.HasPrefix(sql[i:], "/*"):
+			i = skipMySQLBlockComment(sql, i) // oracle block comments do not nest either
+		case c == ';' && !oraclePlSqlUnitRegexp.MatchString(statementHead(sql[start:i])):
+			statements = append(statements, sql[start:i])
+			i++
+			start = i
+		default:
+			i++
+		}
+	}
+	return append(statements, sql[start:])
+}
+
+// maskOracleText returns the oracle statement with its strings, quoted identifiers and comments
+// blanked out, keeping the offsets, so that the clauses the grammar does not accept can be
+// searched without matching their text.
+func maskOracleText(sql string) string {
+	b := []byte(sql)
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		var end int
+		switch {
+		case c == '\'' && i > 0 && (sql[i-1] == 'q' || sql[i-1] == 'Q') && (i == 1 || !isIdentChar(sql[i-2])):
+			end = skipOracleQuoted(sql, i)
+		case c == '\'' || c == '"':
+			end = skipQuoted(sql, i, c, false)
+		case strings.HasPrefix(sql[i:], "--"):
+			end = skipLine(sql, i)
+		case strings.HasPrefix(sql[i:], "/*"):
+			end = skipMySQLBlockComment(sql, i)
+		default:
+			i++
+			continue
+		}
+		blankRange(b, i, end)
+		i = end
+	}
+	return string(b)
+}
+
+// skipOracleQuoted returns the index after the q'[...]' string whose quote is at i, the
+// delimiter is any character, brackets are closed by their counterpart.
+func skipOracleQuoted(s string, i int) int {
+	if i+1 >= len(s) {
+		return len(s)
+	}
+	delimiter := s[i+1]
+	if closing := strings.IndexByte("([{<", delimiter); closing >= 0 {
+		delimiter = ")]}>"[closing]
+	}
+	if end := strings.Index(s[i+2:], string(delimiter)+"'"); end >= 0 {
+		return i + 2 + end + 2
+	}
+	return len(s)
+}
+
+var oraclePlSqlUnitRegexp = regexp.MustCompile(`^(CREATE (OR REPLACE )?((NON)?EDITIONABLE )?(TRIGGER|PROCEDURE|FUNCTION|PACKAGE|TYPE)|DECLARE|BEGIN)\b`)
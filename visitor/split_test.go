@@ -0,0 +1,289 @@
+package visitor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPgStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{
+			name: "semicolons",
+			sql:  "CREATE TABLE a (id int);\nCOMMENT ON TABLE a IS 'x';",
+			want: []string{"CREATE TABLE a (id int)", "\nCOMMENT ON TABLE a IS 'x'", ""},
+		},
+		{
+			name: "strings and quoted identifiers",
+			sql:  `SELECT 'a;b', "c;d";SELECT 'it''s;'`,
+			want: []string{`SELECT 'a;b', "c;d"`, `SELECT 'it''s;'`},
+		},
+		{
+			name: "escape string",
+			sql:  `SELECT E'it\'s;', e'\\';SELECT 1`,
+			want: []string{`SELECT E'it\'s;', e'\\'`, "SELECT 1"},
+		},
+		{
+			name: "backslash in a standard string",
+			sql:  `SELECT 'a\';SELECT 1`,
+			want: []string{`SELECT 'a\'`, "SELECT 1"},
+		},
+		{
+			name: "identifier ending with e",
+			sql:  `SELECT some'a\';SELECT 1`,
+			want: []string{`SELECT some'a\'`, "SELECT 1"},
+		},
+		{
+			name: "dollar quotes",
+			sql:  "CREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql;SELECT 2",
+			want: []string{"CREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql", "SELECT 2"},
+		},
+		{
+			name: "tagged dollar quotes",
+			sql:  "DO $body$ BEGIN PERFORM $$;$$; END $body$;SELECT 2",
+			want: []string{"DO $body$ BEGIN PERFORM $$;$$; END $body$", "SELECT 2"},
+		},
+		{
+			name: "dollar in identifier",
+			sql:  "SELECT a$b$ FROM t;SELECT $1",
+			want: []string{"SELECT a$b$ FROM t", "SELECT $1"},
+		},
+		{
+			name: "unterminated dollar quote",
+			sql:  "SELECT $x$ a; b",
+			want: []string{"SELECT $x$ a; b"},
+		},
+		{
+			name: "comments",
+			sql:  "SELECT 1 -- a;b\n;/* c; /* nested; */ d; */SELECT 2",
+			want: []string{"SELECT 1 -- a;b\n", "/* c; /* nested; */ d; */SELECT 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitPgStatements(tt.sql); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitPgStatements(%q) = %q, want %q", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitMySQLStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{
+			name: "semicolons",
+			sql:  "CREATE TABLE a (id int);\nALTER TABLE a ADD b int;",
+			want: []string{"CREATE TABLE a (id int)", "\nALTER TABLE a ADD b int", ""},
+		},
+		{
+			name: "strings with backslash escapes",
+			sql:  `SELECT 'it\'s;', "a\";b", ` + "`c;d`" + `;SELECT 1`,
+			want: []string{`SELECT 'it\'s;', "a\";b", ` + "`c;d`", "SELECT 1"},
+		},
+		{
+			name: "comments",
+			sql:  "SELECT 1 # a;b\n;SELECT 2 -- c;d\n;SELECT 3 /* e;f */;SELECT 4--5;",
+			want: []string{"SELECT 1 # a;b\n", "SELECT 2 -- c;d\n", "SELECT 3 /* e;f */", "SELECT 4--5", ""},
+		},
+		{
+			name: "delimiter",
+			sql: "CREATE TABLE t (id int);\n" +
+				"DELIMITER ;;\n" +
+				"CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW BEGIN SET NEW.id = 1; SET @a = 2; END ;;\n" +
+				"DELIMITER ;\n" +
+				"SELECT 1;",
+			want: []string{
+				"CREATE TABLE t (id int)",
+				"\n",
+				"CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW BEGIN SET NEW.id = 1; SET @a = 2; END ",
+				"\n",
+				"SELECT 1",
+				"",
+			},
+		},
+		{
+			name: "version comment",
+			sql:  "/*!40101 SET NAMES utf8mb4 */;/*!50003 CREATE*/ /*!50017 DEFINER=`root`@`%`*/ /*!50003 TRIGGER tr */;",
+			want: []string{"/*!40101 SET NAMES utf8mb4 */", "/*!50003 CREATE*/ /*!50017 DEFINER=`root`@`%`*/ /*!50003 TRIGGER tr */", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitMySQLStatements(tt.sql); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitMySQLStatements(%q) = %q, want %q", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveMySQLVersionComments(t *testing.T) {
+	tests := []struct {
+		name    string
+		sql     string
+		version int
+		want    string
+	}{
+		{
+			name:    "kept",
+			sql:     "CREATE TABLE t (id int) /*!50100 PARTITION BY HASH (id) */",
+			version: 80040,
+			want:    "CREATE TABLE t (id int)          PARTITION BY HASH (id)   ",
+		},
+		{
+			name:    "dropped",
+			sql:     "CREATE TABLE t (id int) /*!90000 FOO */x",
+			version: 80040,
+			want:    "CREATE TABLE t (id int)                x",
+		},
+		{
+			name:    "same version",
+			sql:     "/*!80040 a */",
+			version: 80040,
+			want:    "         a   ",
+		},
+		{
+			name:    "without version",
+			sql:     "/*! a */",
+			version: 50700,
+			want:    "    a   ",
+		},
+		{
+			name:    "consecutive",
+			sql:     "/*!50003 CREATE*/ /*!50017 DEFINER=`root`@`%`*/ /*!90000 X*/ /*!50003 TRIGGER tr */",
+			version: 50744,
+			want:    "         CREATE            DEFINER=`root`@`%`                         TRIGGER tr   ",
+		},
+		{
+			name:    "block comment inside",
+			sql:     "/*!50100 a /* b */ c */",
+			version: 80040,
+			want:    "         a /* b */ c   ",
+		},
+		{
+			name:    "nested",
+			sql:     "/*!50100 a /*!90000 b */ c /*!50100 d */ */e",
+			version: 80040,
+			want:    "         a               c          d      e",
+		},
+		{
+			name:    "nested dropped",
+			sql:     "/*!90000 a /*!50100 b */ ';*/' */e",
+			version: 80040,
+			want:    "                                 e",
+		},
+		{
+			name:    "line breaks kept",
+			sql:     "a /*!90000 b\nc */ d",
+			version: 80040,
+			want:    "a           \n     d",
+		},
+		{
+			name:    "in strings and comments",
+			sql:     "'/*!90000 a */' /* /*!90000 b */ # /*!90000 c */\n",
+			version: 80040,
+			want:    "'/*!90000 a */' /* /*!90000 b */ # /*!90000 c */\n",
+		},
+		{
+			name:    "unterminated",
+			sql:     "a /*!90000 b",
+			version: 80040,
+			want:    "a /*!90000 b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveMySQLVersionComments(tt.sql, tt.version); got != tt.want {
+				t.Errorf("resolveMySQLVersionComments(%q, %d) = %q, want %q", tt.sql, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitTSqlStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{
+			name: "go batches",
+			sql:  "CREATE TABLE t (id int)\nGO\nEXEC sp_x\ngo\n",
+			want: []string{"CREATE TABLE t (id int)\n", "EXEC sp_x\n", ""},
+		},
+		{
+			name: "go with count",
+			sql:  "INSERT INTO t DEFAULT VALUES\n  GO 5  \r\nSELECT 1",
+			want: []string{"INSERT INTO t DEFAULT VALUES\n", "SELECT 1"},
+		},
+		{
+			name: "not a go line",
+			sql:  "SELECT 1 AS go\nGO x\nGOTO l\nSELECT 2",
+			want: []string{"SELECT 1 AS go\nGO x\nGOTO l\nSELECT 2"},
+		},
+		{
+			name: "semicolons",
+			sql:  "SELECT 1;SELECT 2;",
+			want: []string{"SELECT 1", "SELECT 2", ""},
+		},
+		{
+			name: "strings, quoted identifiers and comments",
+			sql:  "SELECT N'a;\nGO\n', [b;]]c], \"d;\" -- e;\n/* f; /* g; */ h; */;SELECT 2",
+			want: []string{"SELECT N'a;\nGO\n', [b;]]c], \"d;\" -- e;\n/* f; /* g; */ h; */", "SELECT 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitTSqlStatements(tt.sql); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitTSqlStatements(%q) = %q, want %q", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitOracleStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{
+			name: "slash lines",
+			sql:  "CREATE TABLE t (id NUMBER)\n/\n  /  \nCOMMENT ON TABLE t IS 'x'\n/",
+			want: []string{"CREATE TABLE t (id NUMBER)\n", "", "COMMENT ON TABLE t IS 'x'\n", ""},
+		},
+		{
+			name: "semicolons",
+			sql:  "CREATE TABLE t (id NUMBER);\nCREATE SEQUENCE s;",
+			want: []string{"CREATE TABLE t (id NUMBER)", "\nCREATE SEQUENCE s", ""},
+		},
+		{
+			name: "not a slash line",
+			sql:  "SELECT 4 / 2 FROM dual;",
+			want: []string{"SELECT 4 / 2 FROM dual", ""},
+		},
+		{
+			name: "trigger body",
+			sql:  "CREATE OR REPLACE EDITIONABLE TRIGGER trg BEFORE INSERT ON t FOR EACH ROW\nBEGIN\n  :NEW.id := s.NEXTVAL;\nEND;\n/\nALTER TRIGGER trg ENABLE;",
+			want: []string{"CREATE OR REPLACE EDITIONABLE TRIGGER trg BEFORE INSERT ON t FOR EACH ROW\nBEGIN\n  :NEW.id := s.NEXTVAL;\nEND;\n", "ALTER TRIGGER trg ENABLE", ""},
+		},
+		{
+			name: "strings, quoted identifiers and comments",
+			sql:  "SELECT 'a;\n/\n', q'[b;']', \"c;\" -- d;\n/* e;\n/\n */ FROM dual;SELECT 2",
+			want: []string{"SELECT 'a;\n/\n', q'[b;']', \"c;\" -- d;\n/* e;\n/\n */ FROM dual", "SELECT 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitOracleStatements(tt.sql); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitOracleStatements(%q) = %q, want %q", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
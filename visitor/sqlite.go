@@ -6,17 +6,28 @@ import (
 	parser "github.com/aierdong/createtable-sql-parser/parser/sqlite"
 	"github.com/aierdong/createtable-sql-parser/types"
 	"github.com/antlr4-go/antlr/v4"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 )
 
+var sqliteReferenceAction = regexp.MustCompile(`(?i)\bON\s+(DELETE|UPDATE)\s+(SET\s+NULL|SET\s+DEFAULT|CASCADE|RESTRICT|NO\s+ACTION)`)
+
+var sqliteTableOptions = regexp.MustCompile(`(?is)\)\s*((?:STRICT|WITHOUT\s+ROWID)(?:\s*,\s*(?:STRICT|WITHOUT\s+ROWID))*)\s*;?\s*$`)
+
 type SqliteVisitor struct {
 	*parser.BaseSQLiteParserVisitor
 	Table  *types.AntlrTable
 	Column *types.AntlrColumn
 	Err    error
+
+	strict   bool                     // STRICT table, declared types are enforced
+	comments *antlr.CommonTokenStream // set when inline comments are used
 }
 
-func ParseSqliteSql(sql string) (table *types.AntlrTable, err error) {
+func ParseSqliteSql(sql string, opts ...Option) (table *types.AntlrTable, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			table = nil
@@ -24,6 +35,9 @@ func ParseSqliteSql(sql string) (table *types.AntlrTable, err error) {
 		}
 	}()
 
+	// the grammar only knows WITHOUT ROWID, so the table options are cut off before parsing
+	sql, options := splitSqliteTableOptions(sql)
+
 	lexer := parser.NewSQLiteLexer(antlr.NewInputStream(sql))
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 
@@ -38,6 +52,14 @@ func ParseSqliteSql(sql string) (table *types.AntlrTable, err error) {
 			Dialect: types.SQLite3,
 			Columns: make([]*types.AntlrColumn, 0),
 		},
+		strict: slices.Contains(options, "STRICT"),
+	}
+	visitor.Table.SQLite = &types.SQLiteTable{
+		WithoutRowid: slices.Contains(options, "WITHOUT ROWID"),
+		Strict:       visitor.strict,
+	}
+	if newOptions(opts).inlineComments {
+		visitor.comments = stream
 	}
 	tree.Accept(visitor)
 
@@ -58,39 +80,249 @@ func (v *SqliteVisitor) VisitCreate_table_stmt(ctx *parser.Create_table_stmtCont
 		return nil
 	}
 
-	v.Table.Name = strings.Trim(ctx.Table_name().GetText(), "`\"[]")
+	v.Table.Name, v.Table.Quoted = normalizeIdentifier(types.SQLite3, ctx.Table_name().GetText())
+	if ctx.Schema_name() != nil {
+		v.Table.Schema, _ = normalizeIdentifier(types.SQLite3, ctx.Schema_name().GetText())
+		v.Table.Database = v.Table.Schema
+	}
 
 	for _, col := range ctx.AllColumn_def() {
-		if col.Column_name() == nil || col.Type_name() == nil {
-			v.Err = errors.New("column name or type name is nil")
+		if col.Column_name() == nil {
+			v.Err = errors.New("column name is nil")
 			return nil
 		}
 
-		originalType := strings.Trim(col.Type_name().GetText(), "`\"[]")
-
-		simplifiedType, exists := types.SqliteTypeMap[originalType]
-		if !exists {
-			v.Err = fmt.Errorf("unsupported data type: %s", originalType)
+		column, err := v.parseColumnType(col.Type_name())
+		if err != nil {
+			v.Err = err
 			return nil
 		}
 
-		length := 0
-		if simplifiedType == "string" {
-			length = 50
+		column.Name, column.Quoted = normalizeIdentifier(types.SQLite3, col.Column_name().GetText())
+		for _, c := range col.AllColumn_constraint() {
+			v.setColumnConstraint(column, c)
+		}
+		// sqlite has no COMMENT syntax
+		column.Comment = inlineComment(v.comments, col)
+		v.Table.Columns = append(v.Table.Columns, column)
+	}
+	v.Table.Comment = inlineTableComment(v.comments, ctx, ctx.OPEN_PAR())
+	for _, c := range ctx.AllTable_constraint() {
+		v.addTableConstraint(c)
+	}
+	// in case the table options were not cut off before parsing
+	if ctx.WITHOUT_() != nil {
+		v.Table.SQLite.WithoutRowid = true
+	}
+
+	return nil
+}
+
+// setColumnConstraint sets a column constraint, keys and checks are added to the table constraints.
+func (v *SqliteVisitor) setColumnConstraint(column *types.AntlrColumn, ctx parser.IColumn_constraintContext) {
+	constraint := &types.Constraint{Columns: []string{column.Name}}
+	if ctx.Name() != nil {
+		constraint.Name, _ = normalizeIdentifier(types.SQLite3, ctx.Name().GetText())
+	}
+	switch {
+	case ctx.PRIMARY_() != nil:
+		constraint.Type = types.PrimaryKey
+		// INTEGER PRIMARY KEY AUTOINCREMENT
+		if ctx.AUTOINCREMENT_() != nil {
+			column.AutoIncrement = true
+			column.Identity = newIdentity(true)
+		}
+	case ctx.UNIQUE_() != nil:
+		constraint.Type = types.Unique
+	case ctx.NULL_() != nil:
+		column.NotNull = ctx.NOT_() != nil
+	case ctx.CHECK_() != nil:
+		constraint.Type = types.Check
+		constraint.Check = getOriginalText(ctx.Expr())
+	case ctx.DEFAULT_() != nil:
+		switch {
+		case ctx.Signed_number() != nil:
+			column.Default = getOriginalText(ctx.Signed_number())
+		case ctx.Literal_value() != nil:
+			column.Default = getOriginalText(ctx.Literal_value())
+		default:
+			column.Default = getOriginalText(ctx.Expr())
 		}
-		scale := 0
-		if simplifiedType == "decimal" {
-			length = 31
-			scale = 2
+	case ctx.COLLATE_() != nil:
+		column.Collation, _ = normalizeIdentifier(types.SQLite3, ctx.Collation_name().GetText())
+	case ctx.Foreign_key_clause() != nil:
+		constraint.Type = types.ForeignKey
+		constraint.References = v.getReference(ctx.Foreign_key_clause())
+	case ctx.AS_() != nil:
+		column.Generated = &types.Generated{
+			Expression: getOriginalText(ctx.Expr()),
+			Stored:     ctx.STORED_() != nil,
 		}
+	}
+	if constraint.Type != "" {
+		v.Table.Constraints = append(v.Table.Constraints, constraint)
+	}
+}
 
-		v.Table.Columns = append(v.Table.Columns, &types.AntlrColumn{
-			Name:         strings.Trim(col.Column_name().GetText(), "`\"[]"),
-			DataType:     simplifiedType,
-			StringLength: length,
-			Scale:        scale,
-		})
+// addTableConstraint adds a PRIMARY KEY, UNIQUE, CHECK or FOREIGN KEY table constraint.
+func (v *SqliteVisitor) addTableConstraint(ctx parser.ITable_constraintContext) {
+	constraint := &types.Constraint{}
+	if ctx.Name() != nil {
+		constraint.Name, _ = normalizeIdentifier(types.SQLite3, ctx.Name().GetText())
 	}
+	switch {
+	case ctx.PRIMARY_() != nil, ctx.UNIQUE_() != nil:
+		constraint.Type = If(ctx.PRIMARY_() != nil, types.PrimaryKey, types.Unique)
+		for _, col := range ctx.AllIndexed_column() {
+			if col.Column_name() != nil {
+				name, _ := normalizeIdentifier(types.SQLite3, col.Column_name().GetText())
+				constraint.Columns = append(constraint.Columns, name)
+			} else {
+				constraint.Columns = append(constraint.Columns, getOriginalText(col.Expr()))
+			}
+		}
+	case ctx.CHECK_() != nil:
+		constraint.Type = types.Check
+		constraint.Check = getOriginalText(ctx.Expr())
+	case ctx.FOREIGN_() != nil:
+		constraint.Type = types.ForeignKey
+		for _, col := range ctx.AllColumn_name() {
+			name, _ := normalizeIdentifier(types.SQLite3, col.GetText())
+			constraint.Columns = append(constraint.Columns, name)
+		}
+		constraint.References = v.getReference(ctx.Foreign_key_clause())
+	}
+	v.Table.Constraints = append(v.Table.Constraints, constraint)
+}
 
-	return nil
+// getReference returns the referenced table and columns with the ON DELETE / ON UPDATE actions.
+func (v *SqliteVisitor) getReference(ctx parser.IForeign_key_clauseContext) *types.Reference {
+	reference := &types.Reference{}
+	reference.Table, _ = normalizeIdentifier(types.SQLite3, ctx.Foreign_table().GetText())
+	for _, col := range ctx.AllColumn_name() {
+		name, _ := normalizeIdentifier(types.SQLite3, col.GetText())
+		reference.Columns = append(reference.Columns, name)
+	}
+	for _, action := range sqliteReferenceAction.FindAllStringSubmatch(getOriginalText(ctx), -1) {
+		if strings.EqualFold(action[1], "DELETE") {
+			reference.OnDelete = strings.ToUpper(strings.Join(strings.Fields(action[2]), " "))
+		} else {
+			reference.OnUpdate = strings.ToUpper(strings.Join(strings.Fields(action[2]), " "))
+		}
+	}
+	return reference
+}
+
+// parseColumnType resolves the column type by the declared type name and its affinity,
+// see https://www.sqlite.org/datatype3.html#determination_of_column_affinity
+// A column without a type name has the BLOB affinity.
+func (v *SqliteVisitor) parseColumnType(ctx parser.IType_nameContext) (*types.AntlrColumn, error) {
+	var names []string
+	var args []int
+	if ctx != nil {
+		for _, name := range ctx.AllName() {
+			names = append(names, strings.ToUpper(strings.Trim(name.GetText(), "`\"[]")))
+		}
+		for _, number := range ctx.AllSigned_number() {
+			arg, _ := strconv.ParseFloat(number.GetText(), 64)
+			args = append(args, int(arg))
+		}
+	}
+	declaredType := strings.Join(names, " ")
+
+	length, scale := 0, 0
+	if len(args) > 0 {
+		length = args[0]
+	}
+	if len(args) > 1 {
+		scale = args[1]
+	}
+
+	affinity := sqliteAffinity(declaredType)
+	if v.strict {
+		// STRICT tables only accept these type names and store values of exactly that type
+		switch declaredType {
+		case "INT", "INTEGER", "REAL", "TEXT", "BLOB":
+		case "ANY":
+			// values are stored as given, which is what the BLOB affinity does
+			affinity = "BLOB"
+		default:
+			return nil, fmt.Errorf("unsupported data type in strict table: %s", declaredType)
+		}
+	}
+
+	dataType, exists := types.SqliteTypeMap[declaredType]
+	if !exists {
+		dataType, exists = types.SqliteAffinityMap[affinity]
+	}
+	if !exists {
+		return nil, fmt.Errorf("unsupported data type: %s", declaredType)
+	}
+
+	column := &types.AntlrColumn{DataType: dataType}
+	setSqliteColumnAttributes(column, declaredType, length, scale)
+	return column, nil
+}
+
+// sqliteAffinity returns the type affinity of a declared type, the rules are applied in order.
+func sqliteAffinity(declaredType string) string {
+	switch {
+	case strings.Contains(declaredType, "INT"):
+		return "INTEGER"
+	case strings.Contains(declaredType, "CHAR"),
+		strings.Contains(declaredType, "CLOB"),
+		strings.Contains(declaredType, "TEXT"):
+		return "TEXT"
+	case strings.Contains(declaredType, "BLOB"), declaredType == "":
+		return "BLOB"
+	case strings.Contains(declaredType, "REAL"),
+		strings.Contains(declaredType, "FLOA"),
+		strings.Contains(declaredType, "DOUB"):
+		return "REAL"
+	default:
+		return "NUMERIC"
+	}
+}
+
+// setSqliteColumnAttributes sets the attributes of the column, sqlite does not enforce lengths
+// nor integer sizes, the declared ones are kept as the intended limits.
+func setSqliteColumnAttributes(column *types.AntlrColumn, declaredType string, length int, scale int) {
+	switch column.DataType {
+	case types.String, types.Char:
+		column.StringLength = If(length > 0 && length < 50, length, 50)
+		column.CharLength = length
+		// sqlite text is utf-8 encoded unless the database encoding says otherwise
+		resolveStringLength(column, "", 0)
+	case types.Integer:
+		switch declaredType {
+		case "TINYINT":
+			column.MaxInteger = math.MaxInt8
+		case "SMALLINT", "INT2":
+			column.MaxInteger = math.MaxInt16
+		case "MEDIUMINT":
+			column.MaxInteger = 1<<23 - 1
+		default:
+			// integers are stored in up to 8 bytes
+			column.MaxInteger = math.MaxInt64
+		}
+	case types.Numeric:
+		column.MaxFloat = getMaxFloat64(length)
+		column.Scale = If(scale > 0, scale, 2)
+	case types.Binary:
+		column.StringLength = 50
+	}
+}
+
+// splitSqliteTableOptions cuts the table options, e.g. STRICT or WITHOUT ROWID, off the end of the
+// statement and returns them upper-cased with single spaces.
+func splitSqliteTableOptions(sql string) (string, []string) {
+	matches := sqliteTableOptions.FindStringSubmatchIndex(sql)
+	if matches == nil {
+		return sql, nil
+	}
+	var options []string
+	for _, option := range strings.Split(sql[matches[2]:matches[3]], ",") {
+		options = append(options, strings.ToUpper(strings.Join(strings.Fields(option), " ")))
+	}
+	return sql[:matches[2]], options
 }
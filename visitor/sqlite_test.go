@@ -0,0 +1,144 @@
+package visitor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aierdong/createtable-sql-parser/types"
+)
+
+func TestParseSqliteIdentifierQuoting(t *testing.T) {
+	table, err := ParseSqliteSql("CREATE TABLE \"main\".[My Table] (\"a\"\"b\" INTEGER, `c``d` TEXT, [e f] REAL, Plain TEXT)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Schema != "main" || table.Name != "My Table" || !table.Quoted {
+		t.Errorf("table = %s.%s, Quoted = %v, want main.My Table quoted", table.Schema, table.Name, table.Quoted)
+	}
+	tests := []struct {
+		name   string
+		quoted bool
+	}{
+		{`a"b`, true},
+		{"c`d", true},
+		{"e f", true},
+		{"Plain", false},
+	}
+	if len(table.Columns) != len(tests) {
+		t.Fatalf("columns = %d, want %d", len(table.Columns), len(tests))
+	}
+	for i, tt := range tests {
+		if c := table.Columns[i]; c.Name != tt.name || c.Quoted != tt.quoted {
+			t.Errorf("column %d = %q, Quoted = %v, want %q, %v", i, c.Name, c.Quoted, tt.name, tt.quoted)
+		}
+	}
+}
+
+func TestSqliteAffinity(t *testing.T) {
+	tests := []struct {
+		declaredType string
+		want         string
+	}{
+		{"INT", "INTEGER"},
+		{"UNSIGNED BIG INT", "INTEGER"},
+		{"FLOATING POINT", "INTEGER"}, // INT wins over FLOA
+		{"CHARINT", "INTEGER"},
+		{"VARYING CHARACTER", "TEXT"},
+		{"CLOB", "TEXT"},
+		{"BLOB", "BLOB"},
+		{"", "BLOB"},
+		{"DOUBLE PRECISION", "REAL"},
+		{"FLOAT", "REAL"},
+		{"DECIMAL", "NUMERIC"},
+		{"STRING", "NUMERIC"},
+		{"DATETIME", "NUMERIC"},
+	}
+	for _, tt := range tests {
+		if got := sqliteAffinity(tt.declaredType); got != tt.want {
+			t.Errorf("sqliteAffinity(%q) = %s, want %s", tt.declaredType, got, tt.want)
+		}
+	}
+}
+
+func TestParseSqliteTypes(t *testing.T) {
+	table, err := ParseSqliteSql(`CREATE TABLE t (
+	a VARCHAR(20),
+	b unsigned big int,
+	c DECIMAL(10,5),
+	d varchar(300),
+	e tinyint,
+	f BLOB,
+	g,
+	h STRING,
+	i "double",
+	j nchar(10)
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		dataType     types.DbType
+		stringLength int
+		maxInteger   int64
+		scale        int
+	}{
+		{"a", types.String, 20, 0, 0},
+		{"b", types.Integer, 0, math.MaxInt64, 0},
+		{"c", types.Numeric, 0, 0, 5},
+		{"d", types.String, 50, 0, 0},
+		{"e", types.Integer, 0, math.MaxInt8, 0},
+		{"f", types.Binary, 50, 0, 0},
+		{"g", types.Binary, 50, 0, 0},
+		{"h", types.Numeric, 0, 0, 2},
+		{"i", types.Numeric, 0, 0, 2},
+		{"j", types.Char, 10, 0, 0},
+	}
+	if len(table.Columns) != len(tests) {
+		t.Fatalf("columns = %d, want %d", len(table.Columns), len(tests))
+	}
+	for i, tt := range tests {
+		c := table.Columns[i]
+		if c.Name != tt.name || c.DataType != tt.dataType || c.StringLength != tt.stringLength || c.MaxInteger != tt.maxInteger || c.Scale != tt.scale {
+			t.Errorf("column %s: DataType = %s, StringLength = %d, MaxInteger = %d, Scale = %d, want %s, %d, %d, %d",
+				c.Name, c.DataType, c.StringLength, c.MaxInteger, c.Scale, tt.dataType, tt.stringLength, tt.maxInteger, tt.scale)
+		}
+	}
+	if c := table.Columns[3]; c.CharLength != 300 {
+		t.Errorf("d: CharLength = %d, want the declared 300", c.CharLength)
+	}
+	if c := table.Columns[2]; c.MaxFloat != getMaxFloat64(10) {
+		t.Errorf("c: MaxFloat = %v, want %v", c.MaxFloat, getMaxFloat64(10))
+	}
+}
+
+func TestParseSqliteStrict(t *testing.T) {
+	table, err := ParseSqliteSql(`CREATE TABLE t (a INT, b TEXT, c ANY, d BLOB, e REAL) strict, WITHOUT ROWID`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.SQLite == nil || !table.SQLite.Strict || !table.SQLite.WithoutRowid {
+		t.Errorf("SQLite = %+v, want a strict table without rowid", table.SQLite)
+	}
+	want := []types.DbType{types.Integer, types.String, types.Binary, types.Binary, types.Numeric}
+	for i, c := range table.Columns {
+		if c.DataType != want[i] {
+			t.Errorf("%s: DataType = %s, want %s", c.Name, c.DataType, want[i])
+		}
+	}
+
+	for _, sql := range []string{
+		`CREATE TABLE t (a VARCHAR(10)) STRICT`,
+		`CREATE TABLE t (a) STRICT`,
+	} {
+		if _, err := ParseSqliteSql(sql); err == nil {
+			t.Errorf("ParseSqliteSql(%q) succeeded, want an unsupported type error", sql)
+		}
+	}
+	// the same types are fine without STRICT
+	if _, err := ParseSqliteSql(`CREATE TABLE t (a VARCHAR(10), b)`); err != nil {
+		t.Errorf("non strict table: %v", err)
+	}
+}
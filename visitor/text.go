@@ -0,0 +1,54 @@
+package visitor
+
+import (
+	"encoding/binary"
+	"github.com/antlr4-go/antlr/v4"
+	"strings"
+	"unicode/utf16"
+)
+
+// getOriginalText returns the source text of the rule, unlike GetText the white spaces
+// and comments between tokens are kept.
+func getOriginalText(ctx antlr.ParserRuleContext) string {
+	start, stop := ctx.GetStart(), ctx.GetStop()
+	if start == nil || stop == nil || stop.GetStop() < start.GetStart() {
+		return ctx.GetText()
+	}
+	return start.GetInputStream().GetTextFromInterval(antlr.NewInterval(start.GetStart(), stop.GetStop()))
+}
+
+// getTextAfter returns the source text of the rule following token, e.g. the expression
+// after the DEFAULT keyword.
+func getTextAfter(ctx antlr.ParserRuleContext, token antlr.Token) string {
+	stop := ctx.GetStop()
+	if token == nil || stop == nil || stop.GetStop() <= token.GetStop() {
+		return ""
+	}
+	text := token.GetInputStream().GetTextFromInterval(antlr.NewInterval(token.GetStop()+1, stop.GetStop()))
+	return strings.TrimSpace(text)
+}
+
+// decodeScript returns the script as utf-8: a utf-16 script, e.g. saved by SQL Server Management
+// Studio, is decoded, it is detected by its byte order mark or by the zero byte of its first
+// ascii character. A utf-8 byte order mark is removed.
+func decodeScript(sql string) string {
+	switch {
+	case strings.HasPrefix(sql, "\xff\xfe"):
+		return decodeUTF16(sql[2:], binary.LittleEndian)
+	case strings.HasPrefix(sql, "\xfe\xff"):
+		return decodeUTF16(sql[2:], binary.BigEndian)
+	case len(sql) > 1 && sql[0] != 0 && sql[1] == 0:
+		return decodeUTF16(sql, binary.LittleEndian)
+	case len(sql) > 1 && sql[0] == 0 && sql[1] != 0:
+		return decodeUTF16(sql, binary.BigEndian)
+	}
+	return strings.TrimPrefix(sql, "\ufeff")
+}
+
+func decodeUTF16(s string, order binary.ByteOrder) string {
+	units := make([]uint16, len(s)/2)
+	for i := range units {
+		units[i] = order.Uint16([]byte(s[2*i : 2*i+2]))
+	}
+	return string(utf16.Decode(units))
+}
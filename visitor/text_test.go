@@ -0,0 +1,49 @@
+package visitor
+
+import (
+	"testing"
+	"unicode/utf16"
+)
+
+// encodeUTF16 returns the utf-16 bytes of s in the byte order, with the byte order mark if bom.
+func encodeUTF16(s string, bigEndian, bom bool) string {
+	units := utf16.Encode([]rune(s))
+	if bom {
+		units = append([]uint16{0xfeff}, units...)
+	}
+	b := make([]byte, 0, 2*len(units))
+	for _, u := range units {
+		if bigEndian {
+			b = append(b, byte(u>>8), byte(u))
+		} else {
+			b = append(b, byte(u), byte(u>>8))
+		}
+	}
+	return string(b)
+}
+
+func TestDecodeScript(t *testing.T) {
+	const script = "CREATE TABLE [客户] (id int) -- 😀\r\nGO\r\n"
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{"utf-8", script, script},
+		{"utf-8 with bom", "\xef\xbb\xbf" + script, script},
+		{"utf-16 le with bom", encodeUTF16(script, false, true), script},
+		{"utf-16 be with bom", encodeUTF16(script, true, true), script},
+		{"utf-16 le without bom", encodeUTF16(script, false, false), script},
+		{"utf-16 be without bom", encodeUTF16(script, true, false), script},
+		{"utf-16 le bom only", "\xff\xfe", ""},
+		{"empty", "", ""},
+		{"single byte", "a", "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeScript(tt.sql); got != tt.want {
+				t.Errorf("decodeScript(%q) = %q, want %q", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
@@ -6,8 +6,8 @@ import (
 	parser "github.com/aierdong/createtable-sql-parser/parser/tsql"
 	"github.com/aierdong/createtable-sql-parser/types"
 	"github.com/antlr4-go/antlr/v4"
-	"log"
 	"math"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -20,9 +20,12 @@ type MssqlVisitor struct {
 	Table  *types.AntlrTable
 	Column *types.AntlrColumn
 	Err    error
+
+	comments *antlr.CommonTokenStream // set when inline comments are used
+	property *tsqlExtendedProperty    // set by an EXEC of the extended property procedures
 }
 
-func ParseTSql(sql string) (table *types.AntlrTable, err error) {
+func ParseTSql(sql string, opts ...Option) (table *types.AntlrTable, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			table = nil
@@ -30,12 +33,12 @@ func ParseTSql(sql string) (table *types.AntlrTable, err error) {
 		}
 	}()
 
-	sqls := strings.Split(sql, ";")
+	// scripts of SQL Server Management Studio are utf-16 and separate their batches with GO
+	sqls := splitTSqlStatements(decodeScript(sql))
 
 	for _, s := range sqls {
-		s += ";"
-		if len(s) > 12 && strings.ToUpper(s[:12]) == "CREATE TABLE" {
-			table, err = parseTSqlTable(s)
+		if strings.HasPrefix(statementHead(s), "CREATE TABLE") {
+			table, err = parseTSqlTable(s+";", newOptions(opts))
 			if err != nil {
 				return nil, err
 			}
@@ -48,22 +51,20 @@ func ParseTSql(sql string) (table *types.AntlrTable, err error) {
 	}
 
 	for _, s := range sqls {
-		s = strings.TrimSpace(s)
-		if len(s) > 5 && strings.ToUpper(s[:5]) == "EXEC " {
-			tbl, col, err := parseTSqlComment(s)
+		head := statementHead(s)
+		if strings.HasPrefix(head, "ALTER TABLE") {
+			if err := parseTSqlAlterTable(s, table); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if strings.HasPrefix(head, "EXEC ") || strings.HasPrefix(head, "EXECUTE ") {
+			property, err := parseTSqlExtendedProperty(s)
 			if err != nil {
-				log.Fatal(err)
+				return nil, err
 			}
-			if tbl.Comment != "" {
-				table.Comment = tbl.Comment
-			}
-
-			if col.Name != "" {
-				for _, c := range table.Columns {
-					if strings.ToLower(c.Name) == strings.ToLower(col.Name) {
-						c.Comment = col.Comment
-					}
-				}
+			if property != nil {
+				applyTSqlExtendedProperty(table, property)
 			}
 		}
 	}
@@ -77,19 +78,52 @@ func (v *MssqlVisitor) VisitCreate_table(ctx *parser.Create_tableContext) interf
 		return nil
 	}
 
-	fullTableName := ctx.Table_name().GetText()
-	parts := strings.Split(fullTableName, ".")
-	if len(parts) > 1 {
-		v.Table.Database = strings.Trim(parts[len(parts)-2], "\"")
+	name := ctx.Table_name()
+	if name.GetTable() == nil {
+		v.Err = errors.New("table name is nil")
+		return nil
+	}
+	v.Table.Name, v.Table.Quoted = normalizeIdentifier(types.SQLServer, name.GetTable().GetText())
+	if name.GetSchema() != nil {
+		v.Table.Schema, _ = normalizeIdentifier(types.SQLServer, name.GetSchema().GetText())
+		v.Table.Database = v.Table.Schema
+	}
+	if name.GetDatabase() != nil {
+		v.Table.Catalog, _ = normalizeIdentifier(types.SQLServer, name.GetDatabase().GetText())
 	}
-	v.Table.Name = strings.Trim(parts[len(parts)-1], "\"")
 
 	if ctx.Column_def_table_constraints() == nil {
 		v.Err = errors.New("column def table constraints is nil")
 		return nil
 	}
+	v.Table.Comment = inlineTableComment(v.comments, ctx, ctx.LR_BRACKET())
 
-	for _, child := range ctx.Column_def_table_constraints().AllColumn_def_table_constraint() {
+	v.addColumnsAndConstraints(ctx.Column_def_table_constraints())
+	if len(v.Table.Columns) == 0 {
+		v.Err = errors.New("no column found")
+	}
+
+	// ON [PRIMARY], the filegroup of the table
+	if ctx.On_partition_or_filegroup() != nil && ctx.On_partition_or_filegroup().GetFilegroup() != nil {
+		v.Table.Tablespace, _ = normalizeIdentifier(types.SQLServer, ctx.On_partition_or_filegroup().GetFilegroup().GetText())
+	} else if ctx.ON() != nil {
+		for _, id := range ctx.AllId_() {
+			if id.GetStart().GetTokenIndex() == ctx.ON().GetSymbol().GetTokenIndex()+1 {
+				v.Table.Tablespace, _ = normalizeIdentifier(types.SQLServer, id.GetText())
+			}
+		}
+	}
+	return nil
+}
+
+// addColumnsAndConstraints adds the columns and the table constraints of a CREATE TABLE or
+// ALTER TABLE ADD statement.
+func (v *MssqlVisitor) addColumnsAndConstraints(ctx parser.IColumn_def_table_constraintsContext) {
+	for _, child := range ctx.AllColumn_def_table_constraint() {
+		if child.Table_constraint() != nil {
+			v.addTableConstraint(child.Table_constraint())
+			continue
+		}
 		colDef := child.Column_definition()
 		if colDef == nil {
 			continue
@@ -102,11 +136,173 @@ func (v *MssqlVisitor) VisitCreate_table(ctx *parser.Create_tableContext) interf
 
 		v.Table.Columns = append(v.Table.Columns, col.(*types.AntlrColumn))
 	}
+}
 
-	if len(v.Table.Columns) == 0 {
-		v.Err = errors.New("no column found")
+// addTableConstraint adds a PRIMARY KEY, UNIQUE, FOREIGN KEY or CHECK table constraint,
+// a DEFAULT ... FOR column constraint sets the default of the column.
+func (v *MssqlVisitor) addTableConstraint(ctx parser.ITable_constraintContext) {
+	constraint := &types.Constraint{}
+	if ctx.GetConstraint() != nil {
+		constraint.Name, _ = normalizeIdentifier(types.SQLServer, ctx.GetConstraint().GetText())
 	}
+	switch {
+	case ctx.DEFAULT() != nil && ctx.GetColumn() != nil && ctx.GetConstant_expr() != nil:
+		if col := v.findColumn(ctx.GetColumn().GetText()); col != nil {
+			v.setDefault(col, ctx.GetConstant_expr())
+			col.DefaultName = constraint.Name
+		}
+		return
+	case ctx.PRIMARY() != nil || ctx.UNIQUE() != nil:
+		constraint.Type = If(ctx.PRIMARY() != nil, types.PrimaryKey, types.Unique)
+		constraint.Columns = tsqlIdentifiers(ctx.Column_name_list_with_order().AllId_())
+	case ctx.FOREIGN() != nil && ctx.GetFk() != nil:
+		constraint.Type = types.ForeignKey
+		constraint.Columns = tsqlIdentifiers(ctx.GetFk().AllId_())
+		constraint.References = v.getReference(ctx.Foreign_key_options())
+	case ctx.Check_constraint() != nil:
+		constraint.Type = types.Check
+		constraint.Check = getOriginalText(ctx.Check_constraint().Search_condition())
+	default:
+		return
+	}
+	v.addConstraint(constraint)
+}
 
+// addConstraint adds the constraint to the table, the primary key columns are not null.
+func (v *MssqlVisitor) addConstraint(constraint *types.Constraint) {
+	if constraint.Type == types.PrimaryKey {
+		for _, name := range constraint.Columns {
+			if col := v.findColumn(name); col != nil {
+				col.NotNull = true
+			}
+		}
+	}
+	v.Table.Constraints = append(v.Table.Constraints, constraint)
+}
+
+// getReference returns the referenced table and columns with the ON DELETE / ON UPDATE actions.
+func (v *MssqlVisitor) getReference(ctx parser.IForeign_key_optionsContext) *types.Reference {
+	reference := &types.Reference{Table: tsqlTableName(ctx.Table_name())}
+	if ctx.GetPk() != nil {
+		reference.Columns = tsqlIdentifiers(ctx.GetPk().AllId_())
+	}
+	for _, action := range ctx.AllOn_delete() {
+		reference.OnDelete = tsqlKeyAction(getOriginalText(action))
+	}
+	for _, action := range ctx.AllOn_update() {
+		reference.OnUpdate = tsqlKeyAction(getOriginalText(action))
+	}
+	return reference
+}
+
+// VisitAlter_table applies an ALTER TABLE statement about the table: added columns, defaults
+// and constraints, altered and dropped columns, and dropped constraints.
+func (v *MssqlVisitor) VisitAlter_table(ctx *parser.Alter_tableContext) interface{} {
+	if len(ctx.AllTable_name()) == 0 || !v.isTable(ctx.Table_name(0)) {
+		return nil
+	}
+
+	constraint := &types.Constraint{}
+	if ctx.GetConstraint() != nil {
+		constraint.Name, _ = normalizeIdentifier(types.SQLServer, ctx.GetConstraint().GetText())
+	}
+	add, drop := len(ctx.AllADD()) > 0, len(ctx.AllDROP()) > 0
+	switch {
+	case add && ctx.Column_def_table_constraints() != nil:
+		v.addColumnsAndConstraints(ctx.Column_def_table_constraints())
+	case add && ctx.FOREIGN() != nil:
+		// WITH CHECK ADD CONSTRAINT ... FOREIGN KEY (...) REFERENCES ...
+		constraint.Type = types.ForeignKey
+		constraint.Columns = tsqlIdentifiers(ctx.GetFk().AllId_())
+		constraint.References = &types.Reference{Table: tsqlTableName(ctx.Table_name(1))}
+		if ctx.GetPk() != nil {
+			constraint.References.Columns = tsqlIdentifiers(ctx.GetPk().AllId_())
+		}
+		for _, action := range ctx.AllOn_delete() {
+			constraint.References.OnDelete = tsqlKeyAction(getOriginalText(action))
+		}
+		for _, action := range ctx.AllOn_update() {
+			constraint.References.OnUpdate = tsqlKeyAction(getOriginalText(action))
+		}
+		v.addConstraint(constraint)
+	case add && ctx.Search_condition() != nil:
+		constraint.Type = types.Check
+		constraint.Check = getOriginalText(ctx.Search_condition())
+		v.addConstraint(constraint)
+	case ctx.Column_definition() != nil:
+		// ALTER COLUMN
+		if col, ok := ctx.Column_definition().Accept(v).(*types.AntlrColumn); ok {
+			if c := v.findColumn(col.Name); c != nil {
+				alterTSqlColumn(c, col)
+			}
+		}
+	case drop && ctx.GetConstraint() != nil:
+		for i, c := range v.Table.Constraints {
+			if identifierEqual(types.SQLServer, c.Name, constraint.Name) {
+				v.Table.Constraints = append(v.Table.Constraints[:i], v.Table.Constraints[i+1:]...)
+				break
+			}
+		}
+		for _, c := range v.Table.Columns {
+			if c.DefaultName != "" && identifierEqual(types.SQLServer, c.DefaultName, constraint.Name) {
+				dropTSqlDefault(c)
+			}
+		}
+	case drop:
+		for _, id := range ctx.AllId_() {
+			name, _ := normalizeIdentifier(types.SQLServer, id.GetText())
+			for i, c := range v.Table.Columns {
+				if identifierEqual(types.SQLServer, c.Name, name) {
+					v.Table.Columns = append(v.Table.Columns[:i], v.Table.Columns[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// alterTSqlColumn applies an ALTER COLUMN definition to the column. It changes the type, the
+// collation and the nullability only, the identity, default, comment and other attributes are kept.
+func alterTSqlColumn(col, def *types.AntlrColumn) {
+	col.DataType = def.DataType
+	col.StringLength = def.StringLength
+	col.LengthUnit = def.LengthUnit
+	col.LengthMax = def.LengthMax
+	col.CharLength = def.CharLength
+	col.ByteLength = def.ByteLength
+	col.Charset = def.Charset
+	col.Collation = def.Collation
+	col.MaxInteger = def.MaxInteger
+	col.MinInteger = def.MinInteger
+	col.MaxFloat = def.MaxFloat
+	col.Scale = def.Scale
+	col.NotNull = def.NotNull
+}
+
+// isTable reports whether the table name names the table, the schema is compared when both have one.
+func (v *MssqlVisitor) isTable(ctx parser.ITable_nameContext) bool {
+	if ctx.GetTable() == nil {
+		return false
+	}
+	name, _ := normalizeIdentifier(types.SQLServer, ctx.GetTable().GetText())
+	if !identifierEqual(types.SQLServer, v.Table.Name, name) {
+		return false
+	}
+	if ctx.GetSchema() == nil || v.Table.Schema == "" {
+		return true
+	}
+	schema, _ := normalizeIdentifier(types.SQLServer, ctx.GetSchema().GetText())
+	return identifierEqual(types.SQLServer, v.Table.Schema, schema)
+}
+
+func (v *MssqlVisitor) findColumn(name string) *types.AntlrColumn {
+	name, _ = normalizeIdentifier(types.SQLServer, name)
+	for _, c := range v.Table.Columns {
+		if identifierEqual(types.SQLServer, c.Name, name) {
+			return c
+		}
+	}
 	return nil
 }
 func (v *MssqlVisitor) VisitColumn_definition(ctx *parser.Column_definitionContext) interface{} {
@@ -120,13 +316,142 @@ func (v *MssqlVisitor) VisitColumn_definition(ctx *parser.Column_definitionConte
 		return nil
 	}
 
-	col := ret.(*types.AntlrColumn)
-	return &types.AntlrColumn{
-		Name:         strings.Trim(ctx.Id_().GetText(), "[]"),
-		DataType:     col.DataType,
-		StringLength: col.StringLength,
-		Scale:        col.Scale,
+	column := ret.(*types.AntlrColumn)
+	column.Name, column.Quoted = normalizeIdentifier(types.SQLServer, ctx.Id_().GetText())
+
+	for _, ele := range ctx.AllColumn_definition_element() {
+		v.setColumnElement(column, ele)
+		if v.Err != nil {
+			return nil
+		}
+	}
+
+	// char(n) / varchar(n) hold n bytes, the number of characters depends on the code page of the collation
+	if column.DataType == types.String || column.DataType == types.Char {
+		resolveStringLength(column, If(column.Charset != "", column.Charset, "cp1252"), 0)
+	}
+	column.Comment = inlineComment(v.comments, ctx)
+	return column
+}
+
+// setColumnElement applies COLLATE, IDENTITY(seed, increment), DEFAULT, GENERATED ALWAYS, SPARSE,
+// ROWGUIDCOL, FILESTREAM and the column constraints.
+func (v *MssqlVisitor) setColumnElement(col *types.AntlrColumn, ctx parser.IColumn_definition_elementContext) {
+	if ctx.COLLATE() != nil && ctx.GetCollation_name() != nil {
+		col.Collation, _ = normalizeIdentifier(types.SQLServer, ctx.GetCollation_name().GetText())
+		if col.Charset == "" { // nchar / nvarchar are always ucs2
+			col.Charset = tsqlCollationCharset(col.Collation)
+		}
+		return
+	}
+	if ctx.IDENTITY() != nil {
+		// explicit values need SET IDENTITY_INSERT ON
+		col.AutoIncrement = true
+		col.Identity = newIdentity(false)
+		if ctx.GetSeed() != nil && ctx.GetIncrement() != nil {
+			seed, err := strconv.ParseInt(ctx.GetSeed().GetText(), 10, 64)
+			if err != nil {
+				v.Err = fmt.Errorf("invalid identity seed: %s", ctx.GetSeed().GetText())
+				return
+			}
+			increment, err := strconv.ParseInt(ctx.GetIncrement().GetText(), 10, 64)
+			if err != nil {
+				v.Err = fmt.Errorf("invalid identity increment: %s", ctx.GetIncrement().GetText())
+				return
+			}
+			col.Identity.Seed = seed
+			col.Identity.Increment = increment
+		}
+		return
+	}
+	if ctx.DEFAULT() != nil && ctx.Expression() != nil {
+		v.setDefault(col, ctx.Expression())
+		if ctx.GetConstraint() != nil {
+			col.DefaultName, _ = normalizeIdentifier(types.SQLServer, ctx.GetConstraint().GetText())
+		}
+		return
+	}
+	switch {
+	case ctx.GENERATED() != nil:
+		// the period columns of a temporal table are maintained by the system, HIDDEN keeps them out of SELECT *
+		kind := If(ctx.ROW() != nil, "ROW", If(ctx.TRANSACTION_ID() != nil, "TRANSACTION_ID", "SEQUENCE_NUMBER"))
+		v.sqlServerColumn(col).GeneratedAlways = kind + If(ctx.START() != nil, " START", " END")
+		col.Invisible = ctx.HIDDEN_KEYWORD() != nil
+		return
+	case ctx.SPARSE() != nil:
+		v.sqlServerColumn(col).Sparse = true
+		return
+	case ctx.ROWGUIDCOL() != nil:
+		v.sqlServerColumn(col).RowGuidCol = true
+		return
+	case ctx.FILESTREAM() != nil:
+		v.sqlServerColumn(col).FileStream = true
+		return
+	}
+	if cc := ctx.Column_constraint(); cc != nil {
+		v.setColumnConstraint(col, cc)
+	}
+}
+
+// sqlServerColumn returns the sql server specific attributes of the column, creating them if needed.
+func (v *MssqlVisitor) sqlServerColumn(column *types.AntlrColumn) *types.SQLServerColumn {
+	if column.SQLServer == nil {
+		column.SQLServer = &types.SQLServerColumn{}
+	}
+	return column.SQLServer
+}
+
+// setDefault sets the default expression, DEFAULT NEXT VALUE FOR <sequence> makes the column
+// auto increment.
+func (v *MssqlVisitor) setDefault(col *types.AntlrColumn, expr antlr.ParserRuleContext) {
+	col.Default = getOriginalText(expr)
+	matches := tsqlNextValueRegexp.FindStringSubmatch(expr.GetText())
+	if matches == nil {
+		return
+	}
+	col.AutoIncrement = true
+	col.Identity = newIdentity(true)
+	parts, _ := normalizeQualifiedName(types.SQLServer, matches[1])
+	col.Identity.Sequence = strings.Join(parts, ".")
+}
+
+// dropTSqlDefault removes the default of the column, a column whose values came from
+// DEFAULT NEXT VALUE FOR <sequence> is no longer auto increment.
+func dropTSqlDefault(col *types.AntlrColumn) {
+	if col.Identity != nil && col.Identity.Sequence != "" {
+		col.AutoIncrement = false
+		col.Identity = nil
 	}
+	col.Default = ""
+	col.DefaultName = ""
+}
+
+// setColumnConstraint applies NULL / NOT NULL, the keys and checks are recorded as table
+// constraints of the column.
+func (v *MssqlVisitor) setColumnConstraint(col *types.AntlrColumn, ctx parser.IColumn_constraintContext) {
+	constraint := &types.Constraint{Columns: []string{col.Name}}
+	if ctx.GetConstraint() != nil {
+		constraint.Name, _ = normalizeIdentifier(types.SQLServer, ctx.GetConstraint().GetText())
+	}
+	switch {
+	case ctx.Null_notnull() != nil:
+		col.NotNull = ctx.Null_notnull().NOT() != nil
+		return
+	case ctx.PRIMARY() != nil:
+		col.NotNull = true
+		constraint.Type = types.PrimaryKey
+	case ctx.UNIQUE() != nil:
+		constraint.Type = types.Unique
+	case ctx.Foreign_key_options() != nil:
+		constraint.Type = types.ForeignKey
+		constraint.References = v.getReference(ctx.Foreign_key_options())
+	case ctx.Check_constraint() != nil:
+		constraint.Type = types.Check
+		constraint.Check = getOriginalText(ctx.Check_constraint().Search_condition())
+	default:
+		return
+	}
+	v.Table.Constraints = append(v.Table.Constraints, constraint)
 }
 
 // VisitData_type processes the data type context and returns an AntlrColumn.
@@ -143,7 +468,25 @@ func (v *MssqlVisitor) VisitData_type(ctx *parser.Data_typeContext) interface{}
 		return nil
 	}
 
-	col := &types.AntlrColumn{DataType: simplifiedType}
+	col := &types.AntlrColumn{DataType: simplifiedType, LengthMax: ctx.MAX() != nil}
+
+	// int IDENTITY(1,1) is matched by data_type before column_definition_element
+	if ctx.IDENTITY() != nil {
+		col.AutoIncrement = true
+		col.Identity = newIdentity(false)
+		if ctx.GetSeed() != nil && ctx.GetInc() != nil {
+			if col.Identity.Seed, err = strconv.ParseInt(ctx.GetSeed().GetText(), 10, 64); err != nil {
+				v.Err = fmt.Errorf("invalid identity seed: %s", ctx.GetSeed().GetText())
+				return nil
+			}
+			if col.Identity.Increment, err = strconv.ParseInt(ctx.GetInc().GetText(), 10, 64); err != nil {
+				v.Err = fmt.Errorf("invalid identity increment: %s", ctx.GetInc().GetText())
+				return nil
+			}
+		}
+		v.setColumnAttributes(col, originalType, 0, 0)
+		return col
+	}
 
 	length, scale, err := v.extractLengthAndScale(ctx, originalType)
 	if err != nil {
@@ -158,6 +501,9 @@ func (v *MssqlVisitor) VisitData_type(ctx *parser.Data_typeContext) interface{}
 // extractOriginalType extracts the original type from the context.
 func (v *MssqlVisitor) extractOriginalType(ctx *parser.Data_typeContext) (string, error) {
 	var originalType string
+	if ctx.GetScaled() != nil { // varchar(max), nvarchar(max)
+		originalType = strings.ToLower(strings.Trim(ctx.GetScaled().GetText(), "[]"))
+	}
 	if ctx.Id_() != nil {
 		originalType = strings.ToLower(ctx.Id_().GetText())
 		if ctx.Id_().Keyword() != nil {
@@ -223,17 +569,46 @@ func (v *MssqlVisitor) setColumnAttributes(col *types.AntlrColumn, originalType
 		col.MaxFloat = 214748.3647
 	case "char", "varchar", "text", "nchar", "nvarchar", "ntext":
 		col.StringLength = If(length > 0 && length < 50, length, 50)
+	case "uniqueidentifier":
+		// the text form of a guid
+		col.CharLength = 36
+		col.StringLength = 36
+	case "binary", "varbinary", "image":
+		col.LengthUnit = types.Bytes
+		col.ByteLength = If(length > 0, length, 1)
+		if col.LengthMax || originalType == "image" {
+			col.ByteLength = math.MaxInt32
+		}
+		col.StringLength = If(col.ByteLength < 50, col.ByteLength, 50)
+	}
+
+	// char / varchar lengths are in bytes, nchar / nvarchar lengths are in byte-pairs
+	switch originalType {
+	case "char", "varchar", "text":
+		col.LengthUnit = types.Bytes
+		col.ByteLength = If(length > 0, length, 1)
+		if col.LengthMax || originalType == "text" {
+			col.ByteLength = math.MaxInt32
+		}
+	case "nchar", "nvarchar", "ntext":
+		col.Charset = "ucs2"
+		col.CharLength = If(length > 0, length, 1)
+		if col.LengthMax || originalType == "ntext" {
+			col.CharLength = math.MaxInt32 / 2
+		}
 	}
 }
 
+// VisitExecute_statement reads a call of sp_addextendedproperty, sp_updateextendedproperty or
+// sp_dropextendedproperty, other procedures are ignored.
 func (v *MssqlVisitor) VisitExecute_statement(ctx *parser.Execute_statementContext) interface{} {
 	body := ctx.Execute_body()
 	if !v.isValidBody(body) {
 		return nil
 	}
 
-	procName := v.getProcName(body)
-	if procName != "sp_addextendedproperty" && procName != "sp_updateextendedproperty" {
+	procName := strings.ToLower(v.getProcName(body))
+	if procName != "sp_addextendedproperty" && procName != "sp_updateextendedproperty" && procName != "sp_dropextendedproperty" {
 		return nil
 	}
 
@@ -242,24 +617,22 @@ func (v *MssqlVisitor) VisitExecute_statement(ctx *parser.Execute_statementConte
 		return nil
 	}
 
-	m := v.parseArgs(args)
-	if m == nil {
+	drop := procName == "sp_dropextendedproperty"
+	m := v.parseArgs(args, drop)
+	if m["name"] == "" {
+		// the statement fails on the server, it is skipped
 		return nil
 	}
 
-	if strings.ToLower(m["level2type"]) == "column" {
-		v.Column = &types.AntlrColumn{
-			Name:    strings.Trim(m["level2name"], "'"),
-			Comment: m["value"],
-		}
-		return nil
+	v.property = &tsqlExtendedProperty{
+		name:  m["name"],
+		value: m["value"],
+		drop:  drop,
 	}
-
-	if strings.ToLower(m["level1type"]) == "table" {
-		v.Table.Comment = m["value"]
-		return nil
+	for i := range v.property.levels {
+		level := strconv.Itoa(i)
+		v.property.levels[i] = [2]string{strings.ToUpper(m["level"+level+"type"]), m["level"+level+"name"]}
 	}
-
 	return nil
 }
 
@@ -273,35 +646,134 @@ func (v *MssqlVisitor) isValidBody(body parser.IExecute_bodyContext) bool {
 // Retrieve the procedure name from the body
 func (v *MssqlVisitor) getProcName(body parser.IExecute_bodyContext) string {
 	proc := body.Func_proc_name_server_database_schema().Func_proc_name_database_schema().AllId_()
-	return proc[len(proc)-1].GetText()
+	name, _ := normalizeIdentifier(types.SQLServer, proc[len(proc)-1].GetText())
+	return name
 }
 
-// Parse the arguments and return them as a map
-func (v *MssqlVisitor) parseArgs(args parser.IExecute_statement_argContext) map[string]string {
+// Parse the arguments and return them as a map, keyed by the lower-case parameter names.
+// sp_dropextendedproperty has no @value parameter.
+func (v *MssqlVisitor) parseArgs(args parser.IExecute_statement_argContext, drop bool) map[string]string {
 	m := make(map[string]string)
-	if args.AllExecute_statement_arg_named() == nil || len(args.AllExecute_statement_arg_named()) == 0 {
-		stmt := args.Execute_statement_arg_unnamed()
-		if stmt == nil || strings.ToLower(strings.Trim(stmt.GetValue().GetText(), "N'")) != "ms_description" {
-			return nil
+	if len(args.AllExecute_statement_arg_named()) == 0 {
+		names := tsqlPropertyArgs
+		if drop {
+			names = append([]string{names[0]}, names[2:]...)
 		}
-		allArgs := strings.Split(args.Execute_statement_arg(0).GetText(), ",")
-		for i, arg := range allArgs {
-			m[getTSqlNonamedArgName(i)] = strings.Trim(arg, "N'")
-		}
-	} else {
-		for _, arg := range args.AllExecute_statement_arg_named() {
-			key := strings.Trim(arg.GetName().GetText(), "@")
-			value := strings.Trim(arg.GetValue().GetText(), "N'")
-			m[key] = value
-			if strings.ToLower(key) == "name" && strings.ToLower(value) != "ms_description" {
-				return nil
+		for i, value := range v.unnamedArgs(args) {
+			if i < len(names) {
+				m[names[i]] = value
 			}
 		}
+		return m
+	}
+	for _, arg := range args.AllExecute_statement_arg_named() {
+		key := strings.ToLower(strings.Trim(arg.GetName().GetText(), "@"))
+		m[key] = tsqlParameterValue(arg.GetValue())
 	}
 	return m
 }
 
-func parseTSqlComment(sql string) (*types.AntlrTable, *types.AntlrColumn, error) {
+// unnamedArgs returns the decoded values of the unnamed arguments in order,
+// the grammar nests each following argument in the previous one.
+func (v *MssqlVisitor) unnamedArgs(args parser.IExecute_statement_argContext) []string {
+	var values []string
+	if arg := args.Execute_statement_arg_unnamed(); arg != nil && arg.GetValue() != nil {
+		values = append(values, tsqlParameterValue(arg.GetValue()))
+	}
+	for _, arg := range args.AllExecute_statement_arg() {
+		values = append(values, v.unnamedArgs(arg)...)
+	}
+	return values
+}
+
+// tsqlParameterValue decodes a string parameter, NULL and DEFAULT are empty.
+func tsqlParameterValue(ctx parser.IExecute_parameterContext) string {
+	if ctx.NULL_() != nil || ctx.DEFAULT() != nil {
+		return ""
+	}
+	return decodeString(types.SQLServer, ctx.GetText())
+}
+
+var tsqlPropertyArgs = []string{"name", "value", "level0type", "level0name", "level1type", "level1name", "level2type", "level2name"}
+
+// tsqlExtendedProperty is an extended property added, updated or dropped by a stored procedure.
+type tsqlExtendedProperty struct {
+	name   string
+	value  string
+	drop   bool
+	levels [3][2]string // upper-case type and name of the level 0, 1 and 2 objects
+}
+
+// applyTSqlExtendedProperty applies the property to the table when it targets the schema of the table,
+// the table, or one of its columns, indexes or constraints. MS_Description also sets the comment.
+func applyTSqlExtendedProperty(table *types.AntlrTable, p *tsqlExtendedProperty) {
+	schema, object, child := p.levels[0], p.levels[1], p.levels[2]
+	// USER is the deprecated level 0 type of the schema
+	if schema[0] != "SCHEMA" && schema[0] != "USER" {
+		return
+	}
+	if table.Schema != "" && !identifierEqual(types.SQLServer, table.Schema, schema[1]) {
+		return
+	}
+	if object[0] != "" && (object[0] != "TABLE" || !identifierEqual(types.SQLServer, table.Name, object[1])) {
+		return
+	}
+	if table.SQLServer == nil {
+		table.SQLServer = &types.SQLServerTable{}
+	}
+	if object[0] == "" {
+		table.SQLServer.SchemaProperties = setTSqlProperty(table.SQLServer.SchemaProperties, p)
+		return
+	}
+
+	comment := func(c *string) {
+		if strings.EqualFold(p.name, "MS_Description") {
+			*c = If(p.drop, "", p.value)
+		}
+	}
+	switch child[0] {
+	case "":
+		table.SQLServer.Properties = setTSqlProperty(table.SQLServer.Properties, p)
+		comment(&table.Comment)
+	case "COLUMN":
+		for _, c := range table.Columns {
+			if identifierEqual(types.SQLServer, c.Name, child[1]) {
+				if c.SQLServer == nil {
+					c.SQLServer = &types.SQLServerColumn{}
+				}
+				c.SQLServer.Properties = setTSqlProperty(c.SQLServer.Properties, p)
+				comment(&c.Comment)
+			}
+		}
+	case "INDEX":
+		table.SQLServer.IndexProperties = setTSqlObjectProperty(table.SQLServer.IndexProperties, child[1], p)
+	case "CONSTRAINT":
+		table.SQLServer.ConstraintProperties = setTSqlObjectProperty(table.SQLServer.ConstraintProperties, child[1], p)
+	}
+}
+
+func setTSqlProperty(props map[string]string, p *tsqlExtendedProperty) map[string]string {
+	if p.drop {
+		delete(props, p.name)
+		return props
+	}
+	if props == nil {
+		props = make(map[string]string)
+	}
+	props[p.name] = p.value
+	return props
+}
+
+func setTSqlObjectProperty(objects map[string]map[string]string, name string, p *tsqlExtendedProperty) map[string]map[string]string {
+	if objects == nil {
+		objects = make(map[string]map[string]string)
+	}
+	objects[name] = setTSqlProperty(objects[name], p)
+	return objects
+}
+
+// parseTSqlExtendedProperty parses an EXEC statement, the property is nil for other procedures.
+func parseTSqlExtendedProperty(sql string) (*tsqlExtendedProperty, error) {
 	lexer := parser.NewTSqlLexer(antlr.NewInputStream(sql))
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 	p := parser.NewTSqlParser(stream)
@@ -309,14 +781,28 @@ func parseTSqlComment(sql string) (*types.AntlrTable, *types.AntlrColumn, error)
 	tree := p.Execute_statement()
 	visitor := &MssqlVisitor{
 		BaseTSqlParserVisitor: &parser.BaseTSqlParserVisitor{},
-		Table:                 &types.AntlrTable{},
-		Column:                &types.AntlrColumn{},
 	}
 	tree.Accept(visitor)
-	return visitor.Table, visitor.Column, visitor.Err
+	return visitor.property, visitor.Err
 }
 
-func parseTSqlTable(sql string) (*types.AntlrTable, error) {
+// parseTSqlAlterTable parses an ALTER TABLE statement, it is applied when it is about the table.
+func parseTSqlAlterTable(sql string, table *types.AntlrTable) error {
+	lexer := parser.NewTSqlLexer(antlr.NewInputStream(sql))
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	p := parser.NewTSqlParser(stream)
+
+	tree := p.Alter_table()
+	visitor := &MssqlVisitor{
+		BaseTSqlParserVisitor: &parser.BaseTSqlParserVisitor{},
+		Table:                 table,
+	}
+	tree.Accept(visitor)
+	return visitor.Err
+}
+
+func parseTSqlTable(sql string, o *options) (*types.AntlrTable, error) {
+	sql, sqlServer := extractTSqlTableOptions(sql)
 	lexer := parser.NewTSqlLexer(antlr.NewInputStream(sql))
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 	p := parser.NewTSqlParser(stream)
@@ -330,27 +816,115 @@ func parseTSqlTable(sql string) (*types.AntlrTable, error) {
 			Columns: make([]*types.AntlrColumn, 0),
 		},
 	}
+	if o.inlineComments {
+		visitor.comments = stream
+	}
 	tree.Accept(visitor)
+	visitor.Table.SQLServer = sqlServer
 	return visitor.Table, visitor.Err
 }
 
-func getTSqlNonamedArgName(i int) string {
-	switch i {
-	case 0:
-		return "value"
-	case 1:
-		return "level0type"
-	case 2:
-		return "level0name"
-	case 3:
-		return "level1type"
-	case 4:
-		return "level1name"
-	case 5:
-		return "level2type"
-	case 6:
-		return "level2name"
-	default:
+// extractTSqlTableOptions reads PERIOD FOR SYSTEM_TIME, SYSTEM_VERSIONING, MEMORY_OPTIMIZED and
+// DURABILITY from the CREATE TABLE statement. The grammar knows neither the period nor the history
+// table, so they are blanked out of the returned statement, keeping the offsets of the rest.
+func extractTSqlTableOptions(sql string) (string, *types.SQLServerTable) {
+	b := []byte(sql)
+	var table *types.SQLServerTable
+	options := func() *types.SQLServerTable {
+		if table == nil {
+			table = &types.SQLServerTable{}
+		}
+		return table
+	}
+
+	// the options are searched outside strings and comments
+	masked := maskTSqlText(sql)
+	if m := tsqlPeriodRegexp.FindStringSubmatchIndex(masked); m != nil {
+		options().PeriodStart, _ = normalizeIdentifier(types.SQLServer, sql[m[2]:m[3]])
+		options().PeriodEnd, _ = normalizeIdentifier(types.SQLServer, sql[m[4]:m[5]])
+		blankRange(b, m[0], m[1])
+	}
+	if m := tsqlSystemVersioningRegexp.FindStringSubmatchIndex(masked); m != nil {
+		options().SystemVersioned = strings.EqualFold(sql[m[2]:m[3]], "ON")
+		if m[4] >= 0 {
+			parts, _ := normalizeQualifiedName(types.SQLServer, strings.TrimSpace(sql[m[6]:m[7]]))
+			options().HistoryTable = strings.Join(parts, ".")
+			blankRange(b, m[4], m[5])
+		}
+	}
+	if m := tsqlMemoryOptimizedRegexp.FindStringSubmatch(masked); m != nil {
+		options().MemoryOptimized = strings.EqualFold(m[1], "ON")
+	}
+	if m := tsqlDurabilityRegexp.FindStringSubmatch(masked); m != nil {
+		options().Durability = strings.ToUpper(m[1])
+	}
+	return string(b), table
+}
+
+var (
+	tsqlPeriodRegexp           = regexp.MustCompile(`(?i),\s*PERIOD\s+FOR\s+SYSTEM_TIME\s*\(\s*([^,\s]+)\s*,\s*([^)\s]+)\s*\)`)
+	tsqlSystemVersioningRegexp = regexp.MustCompile(`(?i)\bSYSTEM_VERSIONING\s*=\s*(ON|OFF)(\s*\(\s*HISTORY_TABLE\s*=\s*([^,)]+)[^)]*\))?`)
+	tsqlMemoryOptimizedRegexp  = regexp.MustCompile(`(?i)\bMEMORY_OPTIMIZED\s*=\s*(ON|OFF)\b`)
+	tsqlDurabilityRegexp       = regexp.MustCompile(`(?i)\bDURABILITY\s*=\s*(SCHEMA_AND_DATA|SCHEMA_ONLY)\b`)
+)
+
+// tsqlCollationCharset returns the code page used by char / varchar under a windows or sql collation.
+func tsqlCollationCharset(collation string) string {
+	c := strings.TrimPrefix(strings.ToLower(collation), "sql_")
+	if strings.Contains(c, "_utf8") {
+		return "utf8"
+	}
+	for _, cp := range tsqlCodePages {
+		for _, prefix := range cp.prefixes {
+			if strings.HasPrefix(c, prefix) {
+				return cp.charset
+			}
+		}
+	}
+	return "cp1252"
+}
+
+var tsqlCodePages = []struct {
+	charset  string
+	prefixes []string
+}{
+	{"cp936", []string{"chinese_prc", "chinese_simplified"}},
+	{"cp950", []string{"chinese_taiwan", "chinese_hong_kong", "chinese_traditional"}},
+	{"cp932", []string{"japanese"}},
+	{"cp949", []string{"korean"}},
+	{"cp874", []string{"thai"}},
+	{"cp1250", []string{"albanian", "croatian", "czech", "hungarian", "polish", "romanian", "slovak", "slovenian", "bosnian_latin", "serbian_latin"}},
+	{"cp1251", []string{"cyrillic", "ukrainian", "macedonian", "bosnian_cyrillic", "serbian_cyrillic", "kazakh", "tatar"}},
+	{"cp1253", []string{"greek"}},
+	{"cp1254", []string{"turkish", "azeri_latin", "uzbek_latin"}},
+	{"cp1255", []string{"hebrew"}},
+	{"cp1256", []string{"arabic", "urdu", "persian"}},
+	{"cp1257", []string{"estonian", "latvian", "lithuanian"}},
+	{"cp1258", []string{"vietnamese"}},
+}
+
+// tsqlTableName returns the schema qualified name of the table, e.g. dbo.Users.
+func tsqlTableName(ctx parser.ITable_nameContext) string {
+	parts, _ := normalizeQualifiedName(types.SQLServer, ctx.GetText())
+	return strings.Join(parts, ".")
+}
+
+func tsqlIdentifiers(ids []parser.IId_Context) []string {
+	var names []string
+	for _, id := range ids {
+		name, _ := normalizeIdentifier(types.SQLServer, id.GetText())
+		names = append(names, name)
+	}
+	return names
+}
+
+// tsqlKeyAction returns the action of ON DELETE / ON UPDATE, e.g. SET NULL.
+func tsqlKeyAction(text string) string {
+	fields := strings.Fields(strings.ToUpper(text))
+	if len(fields) < 3 {
 		return ""
 	}
+	return strings.Join(fields[2:], " ")
 }
+
+var tsqlNextValueRegexp = regexp.MustCompile(`(?i)^\(*NEXTVALUEFOR([^)]+)\)*$`)
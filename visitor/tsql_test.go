@@ -0,0 +1,357 @@
+package visitor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/aierdong/createtable-sql-parser/types"
+)
+
+func TestParseTSqlAlterColumn(t *testing.T) {
+	table, err := ParseTSql(`CREATE TABLE [dbo].[t] (
+	[id] int IDENTITY(10,2) NOT NULL,
+	[name] varchar(20) NULL
+)
+GO
+ALTER TABLE [dbo].[t] ADD CONSTRAINT [DF_t_name] DEFAULT ('') FOR [name]
+GO
+ALTER TABLE [dbo].[t] ALTER COLUMN [id] bigint NOT NULL
+GO
+ALTER TABLE [dbo].[t] ALTER COLUMN [name] nvarchar(100) COLLATE Latin1_General_CI_AS NOT NULL
+GO
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := table.Columns[0]
+	if id.MaxInteger != math.MaxInt64 || !id.NotNull {
+		t.Errorf("id: MaxInteger = %d, NotNull = %v, want bigint NOT NULL", id.MaxInteger, id.NotNull)
+	}
+	if !id.AutoIncrement || id.Identity == nil || id.Identity.Seed != 10 || id.Identity.Increment != 2 {
+		t.Errorf("id: identity = %+v, want IDENTITY(10,2) kept", id.Identity)
+	}
+
+	name := table.Columns[1]
+	if name.DataType != types.String || name.CharLength != 100 || name.Charset != "ucs2" || !name.NotNull {
+		t.Errorf("name: DataType = %s, CharLength = %d, Charset = %s, NotNull = %v, want nvarchar(100) NOT NULL",
+			name.DataType, name.CharLength, name.Charset, name.NotNull)
+	}
+	if name.Collation != "Latin1_General_CI_AS" {
+		t.Errorf("name: Collation = %q, want Latin1_General_CI_AS", name.Collation)
+	}
+	if name.Default != "('')" {
+		t.Errorf("name: Default = %q, want ('') kept", name.Default)
+	}
+}
+
+func TestParseTSqlDropDefaultConstraint(t *testing.T) {
+	table, err := ParseTSql(`CREATE TABLE [dbo].[t] (
+	[a] int CONSTRAINT [DF_t_a] DEFAULT ((1)) NOT NULL,
+	[b] int NULL,
+	[c] bigint NOT NULL,
+	[d] int DEFAULT ((4)) NULL,
+	CONSTRAINT [PK_t] PRIMARY KEY CLUSTERED ([c] ASC)
+)
+GO
+ALTER TABLE [dbo].[t] ADD CONSTRAINT [DF_t_b] DEFAULT ((2)) FOR [b]
+GO
+ALTER TABLE [dbo].[t] ADD CONSTRAINT [DF_t_c] DEFAULT (NEXT VALUE FOR [dbo].[seq]) FOR [c]
+GO
+ALTER TABLE [dbo].[t] DROP CONSTRAINT [DF_t_a]
+GO
+ALTER TABLE [dbo].[t] DROP CONSTRAINT [DF_t_c]
+GO
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		column      string
+		defaultName string
+		defaultExpr string
+	}{
+		{"a", "", ""},
+		{"b", "DF_t_b", "((2))"},
+		{"c", "", ""},
+		{"d", "", "((4))"},
+	}
+	for i, tt := range tests {
+		col := table.Columns[i]
+		if col.Name != tt.column || col.DefaultName != tt.defaultName || col.Default != tt.defaultExpr {
+			t.Errorf("column %s: DefaultName = %q, Default = %q, want %q, %q",
+				col.Name, col.DefaultName, col.Default, tt.defaultName, tt.defaultExpr)
+		}
+	}
+	if c := table.Columns[2]; c.AutoIncrement || c.Identity != nil {
+		t.Errorf("column c: AutoIncrement = %v after its sequence default was dropped", c.AutoIncrement)
+	}
+	if len(table.Constraints) != 1 || table.Constraints[0].Name != "PK_t" {
+		t.Errorf("constraints = %+v, want PK_t only", table.Constraints)
+	}
+}
+
+func TestParseTSqlStringLength(t *testing.T) {
+	table, err := ParseTSql(`CREATE TABLE t (
+	a varchar(10),
+	b nvarchar(10),
+	c varchar(max),
+	d nvarchar(max),
+	e varchar(10) COLLATE Chinese_PRC_CI_AS,
+	f char
+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkLengths(t, table, []lengthCase{
+		{"a", types.Bytes, 10, 10, ""},
+		{"b", types.Chars, 10, 20, "ucs2"},
+		{"c", types.Bytes, math.MaxInt32, math.MaxInt32, ""},
+		{"d", types.Chars, math.MaxInt32 / 2, math.MaxInt32 / 2 * 2, "ucs2"},
+		{"e", types.Bytes, 5, 10, "cp936"},
+		{"f", types.Bytes, 1, 1, ""},
+	})
+	if !table.Columns[2].LengthMax || !table.Columns[3].LengthMax || table.Columns[0].LengthMax {
+		t.Errorf("LengthMax = %v, %v, %v, want only the (max) columns", table.Columns[0].LengthMax, table.Columns[2].LengthMax, table.Columns[3].LengthMax)
+	}
+}
+
+func TestExtractTSqlTableOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want *types.SQLServerTable
+	}{
+		{
+			name: "none",
+			sql:  "CREATE TABLE t (id int)",
+		},
+		{
+			name: "temporal",
+			sql: "CREATE TABLE t (id int, [From] datetime2, [To] datetime2, PERIOD FOR SYSTEM_TIME ([From], [To]))\n" +
+				"WITH (SYSTEM_VERSIONING = ON (HISTORY_TABLE = [dbo].[t_history], DATA_CONSISTENCY_CHECK = ON))",
+			want: &types.SQLServerTable{PeriodStart: "From", PeriodEnd: "To", SystemVersioned: true, HistoryTable: "dbo.t_history"},
+		},
+		{
+			name: "versioning off",
+			sql:  "CREATE TABLE t (id int) WITH (system_versioning = off)",
+			want: &types.SQLServerTable{},
+		},
+		{
+			name: "memory optimized",
+			sql:  "CREATE TABLE t (id int) WITH (MEMORY_OPTIMIZED = ON, DURABILITY = schema_only)",
+			want: &types.SQLServerTable{MemoryOptimized: true, Durability: "SCHEMA_ONLY"},
+		},
+		{
+			name: "in comments and strings",
+			sql:  "CREATE TABLE t (id int DEFAULT 'MEMORY_OPTIMIZED = ON') -- WITH (SYSTEM_VERSIONING = ON)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, table := extractTSqlTableOptions(tt.sql)
+			if !reflect.DeepEqual(table, tt.want) {
+				t.Errorf("options = %+v, want %+v", table, tt.want)
+			}
+			if len(sql) != len(tt.sql) {
+				t.Errorf("len(sql) = %d, want the offsets kept, %d", len(sql), len(tt.sql))
+			}
+		})
+	}
+}
+
+func TestParseTSqlTemporalTable(t *testing.T) {
+	table, err := ParseTSql(`CREATE TABLE [dbo].[t] (
+	[id] int NOT NULL PRIMARY KEY CLUSTERED,
+	[doc] varbinary(max) FILESTREAM NULL,
+	[guid] uniqueidentifier ROWGUIDCOL NOT NULL UNIQUE,
+	[note] nvarchar(100) SPARSE NULL,
+	[ValidFrom] datetime2 GENERATED ALWAYS AS ROW START HIDDEN NOT NULL,
+	[ValidTo] datetime2 GENERATED ALWAYS AS ROW END NOT NULL,
+	PERIOD FOR SYSTEM_TIME ([ValidFrom], [ValidTo])
+)
+WITH (SYSTEM_VERSIONING = ON (HISTORY_TABLE = [dbo].[t_history]))
+GO
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(table.Columns) != 6 {
+		t.Fatalf("columns = %d, want 6 without the period", len(table.Columns))
+	}
+	want := &types.SQLServerTable{PeriodStart: "ValidFrom", PeriodEnd: "ValidTo", SystemVersioned: true, HistoryTable: "dbo.t_history"}
+	if !reflect.DeepEqual(table.SQLServer, want) {
+		t.Errorf("SQLServer = %+v, want %+v", table.SQLServer, want)
+	}
+
+	tests := []struct {
+		column    string
+		sqlServer *types.SQLServerColumn
+		invisible bool
+	}{
+		{"id", nil, false},
+		{"doc", &types.SQLServerColumn{FileStream: true}, false},
+		{"guid", &types.SQLServerColumn{RowGuidCol: true}, false},
+		{"note", &types.SQLServerColumn{Sparse: true}, false},
+		{"ValidFrom", &types.SQLServerColumn{GeneratedAlways: "ROW START"}, true},
+		{"ValidTo", &types.SQLServerColumn{GeneratedAlways: "ROW END"}, false},
+	}
+	for i, tt := range tests {
+		c := table.Columns[i]
+		if c.Name != tt.column || !reflect.DeepEqual(c.SQLServer, tt.sqlServer) || c.Invisible != tt.invisible {
+			t.Errorf("column %s: SQLServer = %+v, Invisible = %v, want %+v, %v", c.Name, c.SQLServer, c.Invisible, tt.sqlServer, tt.invisible)
+		}
+	}
+	if c := table.Columns[4]; !c.NotNull {
+		t.Errorf("ValidFrom: NotNull = %v, want NOT NULL after HIDDEN", c.NotNull)
+	}
+	if c := table.Columns[1]; c.DataType != types.Binary || !c.LengthMax || c.ByteLength != math.MaxInt32 {
+		t.Errorf("doc: DataType = %s, LengthMax = %v, ByteLength = %d, want varbinary(max)", c.DataType, c.LengthMax, c.ByteLength)
+	}
+	if c := table.Columns[2]; c.DataType != types.String || c.CharLength != 36 || !c.NotNull {
+		t.Errorf("guid: DataType = %s, CharLength = %d, NotNull = %v, want a uniqueidentifier", c.DataType, c.CharLength, c.NotNull)
+	}
+}
+
+func TestParseTSqlMemoryOptimized(t *testing.T) {
+	table, err := ParseTSql(`CREATE TABLE dbo.cache (
+	id int NOT NULL PRIMARY KEY NONCLUSTERED,
+	value nvarchar(200) NULL
+) WITH (MEMORY_OPTIMIZED = ON, DURABILITY = SCHEMA_ONLY)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &types.SQLServerTable{MemoryOptimized: true, Durability: "SCHEMA_ONLY"}
+	if !reflect.DeepEqual(table.SQLServer, want) {
+		t.Errorf("SQLServer = %+v, want %+v", table.SQLServer, want)
+	}
+}
+
+func TestApplyTSqlExtendedProperty(t *testing.T) {
+	property := func(name, value string, drop bool, levels ...string) *tsqlExtendedProperty {
+		p := &tsqlExtendedProperty{name: name, value: value, drop: drop}
+		for i := 0; i+1 < len(levels); i += 2 {
+			p.levels[i/2] = [2]string{levels[i], levels[i+1]}
+		}
+		return p
+	}
+	tests := []struct {
+		name     string
+		property *tsqlExtendedProperty
+		want     *types.SQLServerTable
+		comment  string
+	}{
+		{
+			name:     "table",
+			property: property("MS_Description", "orders", false, "SCHEMA", "dbo", "TABLE", "Orders"),
+			want:     &types.SQLServerTable{Properties: map[string]string{"MS_Description": "orders"}},
+			comment:  "orders",
+		},
+		{
+			name:     "user as schema",
+			property: property("Owner", "sales", false, "USER", "DBO", "TABLE", "orders"),
+			want:     &types.SQLServerTable{Properties: map[string]string{"Owner": "sales"}},
+		},
+		{
+			name:     "schema",
+			property: property("Owner", "it", false, "SCHEMA", "dbo"),
+			want:     &types.SQLServerTable{SchemaProperties: map[string]string{"Owner": "it"}},
+		},
+		{
+			name:     "index",
+			property: property("MS_Description", "by date", false, "SCHEMA", "dbo", "TABLE", "orders", "INDEX", "IX_orders_date"),
+			want:     &types.SQLServerTable{IndexProperties: map[string]map[string]string{"IX_orders_date": {"MS_Description": "by date"}}},
+		},
+		{
+			name:     "constraint",
+			property: property("MS_Description", "key", false, "SCHEMA", "dbo", "TABLE", "orders", "CONSTRAINT", "PK_orders"),
+			want:     &types.SQLServerTable{ConstraintProperties: map[string]map[string]string{"PK_orders": {"MS_Description": "key"}}},
+		},
+		{
+			name:     "other schema",
+			property: property("MS_Description", "x", false, "SCHEMA", "sales", "TABLE", "orders"),
+		},
+		{
+			name:     "other table",
+			property: property("MS_Description", "x", false, "SCHEMA", "dbo", "TABLE", "items"),
+		},
+		{
+			name:     "not a schema object",
+			property: property("MS_Description", "x", false, "FILEGROUP", "PRIMARY"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := &types.AntlrTable{Schema: "dbo", Name: "orders"}
+			applyTSqlExtendedProperty(table, tt.property)
+			if !reflect.DeepEqual(table.SQLServer, tt.want) || table.Comment != tt.comment {
+				t.Errorf("SQLServer = %+v, Comment = %q, want %+v, %q", table.SQLServer, table.Comment, tt.want, tt.comment)
+			}
+		})
+	}
+
+	// update replaces the value, drop removes the property and the comment
+	table := &types.AntlrTable{Schema: "dbo", Name: "orders", Columns: []*types.AntlrColumn{{Name: "Note"}}}
+	applyTSqlExtendedProperty(table, property("MS_Description", "a", false, "SCHEMA", "dbo", "TABLE", "orders", "COLUMN", "note"))
+	applyTSqlExtendedProperty(table, property("Caption", "c", false, "SCHEMA", "dbo", "TABLE", "orders", "COLUMN", "note"))
+	applyTSqlExtendedProperty(table, property("MS_Description", "b", false, "SCHEMA", "dbo", "TABLE", "orders", "COLUMN", "note"))
+	if c := table.Columns[0]; c.Comment != "b" || !reflect.DeepEqual(c.SQLServer.Properties, map[string]string{"MS_Description": "b", "Caption": "c"}) {
+		t.Errorf("updated column: Comment = %q, Properties = %v", c.Comment, c.SQLServer.Properties)
+	}
+	applyTSqlExtendedProperty(table, property("MS_Description", "", true, "SCHEMA", "dbo", "TABLE", "orders", "COLUMN", "note"))
+	if c := table.Columns[0]; c.Comment != "" || !reflect.DeepEqual(c.SQLServer.Properties, map[string]string{"Caption": "c"}) {
+		t.Errorf("dropped column property: Comment = %q, Properties = %v", c.Comment, c.SQLServer.Properties)
+	}
+}
+
+func TestParseTSqlExtendedProperties(t *testing.T) {
+	table, err := ParseTSql(`CREATE TABLE [dbo].[orders] (
+	[id] int NOT NULL CONSTRAINT [PK_orders] PRIMARY KEY,
+	[note] nvarchar(10) NULL
+)
+GO
+EXEC sys.sp_addextendedproperty @name = N'MS_Description', @value = N'Orders',
+	@level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'orders'
+GO
+EXEC sp_addextendedproperty N'Owner', N'sales', N'SCHEMA', N'dbo', N'TABLE', N'orders'
+GO
+EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'All orders',
+	@level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'orders'
+GO
+EXEC sp_dropextendedproperty N'Owner', N'SCHEMA', N'dbo', N'TABLE', N'orders'
+GO
+EXEC sp_addextendedproperty N'MS_Description', N'It''s a note', N'USER', N'dbo', N'TABLE', N'orders', N'COLUMN', N'note'
+GO
+EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'Key',
+	@level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'orders',
+	@level2type = N'CONSTRAINT', @level2name = N'PK_orders'
+GO
+EXEC sp_addextendedproperty @value = N'no name', @level0type = N'SCHEMA', @level0name = N'dbo'
+GO
+EXEC sp_rename N'dbo.orders.note', N'remark', N'COLUMN'
+GO
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &types.SQLServerTable{
+		Properties:           map[string]string{"MS_Description": "All orders"},
+		ConstraintProperties: map[string]map[string]string{"PK_orders": {"MS_Description": "Key"}},
+	}
+	if !reflect.DeepEqual(table.SQLServer, want) {
+		t.Errorf("SQLServer = %+v, want %+v", table.SQLServer, want)
+	}
+	if table.Comment != "All orders" {
+		t.Errorf("table comment = %q, want All orders", table.Comment)
+	}
+	if note := table.Columns[1]; note.Comment != "It's a note" || note.SQLServer == nil || note.SQLServer.Properties["MS_Description"] != "It's a note" {
+		t.Errorf("note: Comment = %q, SQLServer = %+v, want It's a note", note.Comment, note.SQLServer)
+	}
+	if note := table.Columns[1]; note.Name != "note" {
+		t.Errorf("column = %q, want sp_rename ignored", note.Name)
+	}
+}